@@ -0,0 +1,783 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ---------------------------------------------------------------------------
+// Jira Agile API response types
+// ---------------------------------------------------------------------------
+
+// JiraPage is the startAt/maxResults envelope used by /rest/agile/1.0 list
+// endpoints and by a few platform endpoints such as the issue changelog.
+type JiraPage[T any] struct {
+	StartAt    int  `json:"startAt"`
+	MaxResults int  `json:"maxResults"`
+	Total      int  `json:"total"`
+	IsLast     bool `json:"isLast"`
+	Values     []T  `json:"values"`
+}
+
+type JiraBoard struct {
+	ID       int                `json:"id"`
+	Name     string             `json:"name"`
+	Type     string             `json:"type"`
+	Location *JiraBoardLocation `json:"location"`
+}
+
+type JiraBoardLocation struct {
+	ProjectKey  string `json:"projectKey"`
+	ProjectName string `json:"projectName"`
+}
+
+type JiraSprint struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	State         string `json:"state"`
+	Goal          string `json:"goal"`
+	StartDate     string `json:"startDate"`
+	EndDate       string `json:"endDate"`
+	CompleteDate  string `json:"completeDate"`
+	OriginBoardID int    `json:"originBoardId"`
+}
+
+type JiraMoveIssuesRequest struct {
+	Issues []string `json:"issues"`
+}
+
+type JiraRankRequest struct {
+	Issues          []string `json:"issues"`
+	RankBeforeIssue string   `json:"rankBeforeIssue,omitempty"`
+	RankAfterIssue  string   `json:"rankAfterIssue,omitempty"`
+}
+
+// JiraRankResponse is returned with 207 Multi-Status when some issues could
+// not be ranked.
+type JiraRankResponse struct {
+	Entries []JiraRankEntry `json:"entries"`
+}
+
+type JiraRankEntry struct {
+	IssueKey string   `json:"issueKey"`
+	Status   int      `json:"status"`
+	Errors   []string `json:"errors"`
+}
+
+type JiraSprintIssuesResponse struct {
+	StartAt    int         `json:"startAt"`
+	MaxResults int         `json:"maxResults"`
+	Total      int         `json:"total"`
+	Issues     []JiraIssue `json:"issues"`
+}
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type BoardView struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Project string `json:"project,omitempty"`
+	URL     string `json:"url"`
+}
+
+type BoardListView struct {
+	Server  string      `json:"server"`
+	Count   int         `json:"count"`
+	HasMore bool        `json:"has_more"`
+	Boards  []BoardView `json:"boards"`
+}
+
+type SprintView struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	State     string `json:"state"`
+	Goal      string `json:"goal,omitempty"`
+	Start     string `json:"start,omitempty"`
+	End       string `json:"end,omitempty"`
+	Completed string `json:"completed,omitempty"`
+	BoardID   int    `json:"board_id,omitempty"`
+}
+
+type SprintMoveResult struct {
+	Target string   `json:"target"`
+	Sprint int      `json:"sprint,omitempty"`
+	Issues []string `json:"issues"`
+	DryRun bool     `json:"dry_run,omitempty"`
+}
+
+type RankResult struct {
+	Issues []string `json:"issues"`
+	Before string   `json:"before,omitempty"`
+	After  string   `json:"after,omitempty"`
+	DryRun bool     `json:"dry_run,omitempty"`
+	URL    string   `json:"url"`
+}
+
+type SprintListView struct {
+	Server  string       `json:"server"`
+	BoardID int          `json:"board_id"`
+	Count   int          `json:"count"`
+	HasMore bool         `json:"has_more"`
+	Sprints []SprintView `json:"sprints"`
+}
+
+// ---------------------------------------------------------------------------
+// Help functions
+// ---------------------------------------------------------------------------
+
+func printBoardsHelp() {
+	fmt.Println("jiractl boards commands:")
+	fmt.Println("  boards list  [--project KEY] [--type scrum|kanban] [--name TEXT] [--limit N] [--json]")
+}
+
+func printSprintsHelp() {
+	fmt.Println("jiractl sprints commands:")
+	fmt.Println("  sprints list    --board ID [--state active|future|closed] [--limit N] [--json]")
+	fmt.Println("  sprints view    SPRINT-ID [--json]")
+	fmt.Println("  sprints issues  SPRINT-ID [--limit N] [--json]")
+	fmt.Println("  sprints add     SPRINT-ID ISSUE-KEY... [--dry-run] [--json]")
+	fmt.Println("  sprints remove  ISSUE-KEY... [--dry-run] [--json]")
+	fmt.Println("  sprints report  SPRINT-ID [--points-field FIELD] [--json|--markdown]")
+}
+
+// ---------------------------------------------------------------------------
+// Boards commands
+// ---------------------------------------------------------------------------
+
+func runBoards(args []string) error {
+	if len(args) == 0 {
+		printBoardsHelp()
+		return nil
+	}
+
+	switch args[0] {
+	case "list":
+		return runBoardsList(args[1:])
+	case "help", "--help", "-h":
+		printBoardsHelp()
+		return nil
+	default:
+		printBoardsHelp()
+		return fmt.Errorf("unknown boards command %q", args[0])
+	}
+}
+
+func runBoardsList(args []string) error {
+	fs := flag.NewFlagSet("boards list", flag.ContinueOnError)
+	project := fs.String("project", "", "only boards for this project key")
+	boardType := fs.String("type", "", "board type (scrum or kanban)")
+	name := fs.String("name", "", "only boards whose name contains TEXT")
+	limit := fs.Int("limit", 50, "max boards to return")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *limit <= 0 {
+		return errors.New("--limit must be greater than 0")
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	q := url.Values{}
+	if *project != "" {
+		q.Set("projectKeyOrId", strings.ToUpper(*project))
+	}
+	if *boardType != "" {
+		q.Set("type", strings.ToLower(*boardType))
+	}
+	if *name != "" {
+		q.Set("name", *name)
+	}
+
+	boards, hasMore, err := getPagedValues[JiraBoard](cfg, "/rest/agile/1.0/board", q, *limit)
+	if err != nil {
+		return err
+	}
+
+	out := BoardListView{
+		Server:  cfg.Server,
+		Count:   len(boards),
+		HasMore: hasMore,
+		Boards:  make([]BoardView, 0, len(boards)),
+	}
+	for _, b := range boards {
+		out.Boards = append(out.Boards, boardToView(b, cfg.Server))
+	}
+
+	if *jsonOut {
+		return printJSON(out)
+	}
+
+	if len(out.Boards) == 0 {
+		fmt.Println("No boards found.")
+		return nil
+	}
+	fmt.Printf("Boards (%d):\n", len(out.Boards))
+	for _, b := range out.Boards {
+		fmt.Printf("- %-6d  [%s]  %s", b.ID, b.Type, b.Name)
+		if b.Project != "" {
+			fmt.Printf("  (%s)", b.Project)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Sprints commands
+// ---------------------------------------------------------------------------
+
+func runSprints(args []string) error {
+	if len(args) == 0 {
+		printSprintsHelp()
+		return nil
+	}
+
+	switch args[0] {
+	case "list":
+		return runSprintsList(args[1:])
+	case "view":
+		return runSprintsView(args[1:])
+	case "issues":
+		return runSprintsIssues(args[1:])
+	case "add":
+		return runSprintsAdd(args[1:])
+	case "remove":
+		return runSprintsRemove(args[1:])
+	case "report":
+		return runSprintsReport(args[1:])
+	case "help", "--help", "-h":
+		printSprintsHelp()
+		return nil
+	default:
+		printSprintsHelp()
+		return fmt.Errorf("unknown sprints command %q", args[0])
+	}
+}
+
+func runSprintsList(args []string) error {
+	fs := flag.NewFlagSet("sprints list", flag.ContinueOnError)
+	board := fs.Int("board", 0, "board ID (required)")
+	state := fs.String("state", "", "comma-separated sprint states: active, future, closed")
+	limit := fs.Int("limit", 50, "max sprints to return")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *board <= 0 {
+		return errors.New("--board is required (see: jiractl boards list)")
+	}
+	if *limit <= 0 {
+		return errors.New("--limit must be greater than 0")
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	q := url.Values{}
+	if *state != "" {
+		q.Set("state", strings.ToLower(strings.ReplaceAll(*state, " ", "")))
+	}
+
+	path := fmt.Sprintf("/rest/agile/1.0/board/%d/sprint", *board)
+	sprints, hasMore, err := getPagedValues[JiraSprint](cfg, path, q, *limit)
+	if err != nil {
+		return err
+	}
+
+	out := SprintListView{
+		Server:  cfg.Server,
+		BoardID: *board,
+		Count:   len(sprints),
+		HasMore: hasMore,
+		Sprints: make([]SprintView, 0, len(sprints)),
+	}
+	for _, s := range sprints {
+		out.Sprints = append(out.Sprints, sprintToView(s))
+	}
+
+	if *jsonOut {
+		return printJSON(out)
+	}
+
+	if len(out.Sprints) == 0 {
+		fmt.Println("No sprints found.")
+		return nil
+	}
+	fmt.Printf("Sprints on board %d (%d):\n", *board, len(out.Sprints))
+	for _, s := range out.Sprints {
+		fmt.Printf("- %-6d  [%s]  %s", s.ID, s.State, s.Name)
+		if s.Start != "" || s.End != "" {
+			fmt.Printf("  (%s .. %s)", s.Start, s.End)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+func runSprintsView(args []string) error {
+	fs := flag.NewFlagSet("sprints view", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	sprintID, err := sprintIDArg(positional, "jiractl sprints view 42")
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	sprint, err := getSprint(cfg, sprintID)
+	if err != nil {
+		return err
+	}
+	view := sprintToView(sprint)
+
+	if *jsonOut {
+		return printJSON(view)
+	}
+
+	fmt.Printf("ID:          %d\n", view.ID)
+	fmt.Printf("Name:        %s\n", view.Name)
+	fmt.Printf("State:       %s\n", view.State)
+	fmt.Printf("Start:       %s\n", view.Start)
+	fmt.Printf("End:         %s\n", view.End)
+	if view.Completed != "" {
+		fmt.Printf("Completed:   %s\n", view.Completed)
+	}
+	if view.BoardID != 0 {
+		fmt.Printf("Board:       %d\n", view.BoardID)
+	}
+	if view.Goal != "" {
+		fmt.Printf("\nGoal:\n%s\n", view.Goal)
+	}
+	return nil
+}
+
+func runSprintsIssues(args []string) error {
+	fs := flag.NewFlagSet("sprints issues", flag.ContinueOnError)
+	limit := fs.Int("limit", 50, "max issues to return")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if *limit <= 0 {
+		return errors.New("--limit must be greater than 0")
+	}
+
+	sprintID, err := sprintIDArg(positional, "jiractl sprints issues 42")
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	searchResult, err := getSprintIssues(cfg, sprintID, *limit)
+	if err != nil {
+		return err
+	}
+
+	views := issuesToViews(searchResult.Issues, cfg.Server)
+	out := IssueListView{
+		Server:  cfg.Server,
+		Count:   len(views),
+		Total:   searchResult.Total,
+		HasMore: searchResult.HasMore,
+		Issues:  views,
+	}
+
+	if *jsonOut {
+		return printJSON(out)
+	}
+
+	if len(views) == 0 {
+		fmt.Println("No issues in sprint.")
+		return nil
+	}
+
+	if out.Total > len(views) || out.HasMore {
+		fmt.Printf("Sprint %d issues (%d of %d):\n", sprintID, len(views), out.Total)
+	} else {
+		fmt.Printf("Sprint %d issues (%d):\n", sprintID, len(views))
+	}
+	for _, v := range views {
+		fmt.Printf("- %-12s  [%s]  %s\n", v.Key, v.Status, v.Summary)
+	}
+	return nil
+}
+
+func runSprintsAdd(args []string) error {
+	fs := flag.NewFlagSet("sprints add", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "show what would change without calling Jira")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	sprintID, err := sprintIDArg(positional, "jiractl sprints add 42 PROJ-1 PROJ-2")
+	if err != nil {
+		return err
+	}
+	keys := issueKeysArg(positional[1:])
+	if len(keys) == 0 {
+		return errors.New("at least one issue key is required (e.g. jiractl sprints add 42 PROJ-1 PROJ-2)")
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	if !*dryRun {
+		if err := moveIssuesToSprint(cfg, sprintID, keys); err != nil {
+			return err
+		}
+	}
+
+	result := SprintMoveResult{
+		Target: "sprint",
+		Sprint: sprintID,
+		Issues: keys,
+		DryRun: *dryRun,
+	}
+
+	if *jsonOut {
+		return printJSON(result)
+	}
+
+	if *dryRun {
+		fmt.Printf("Would move %d issue(s) to sprint %d: %s\n", len(keys), sprintID, strings.Join(keys, ", "))
+		return nil
+	}
+	fmt.Printf("Moved %d issue(s) to sprint %d: %s\n", len(keys), sprintID, strings.Join(keys, ", "))
+	return nil
+}
+
+func runSprintsRemove(args []string) error {
+	fs := flag.NewFlagSet("sprints remove", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "show what would change without calling Jira")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	keys := issueKeysArg(positional)
+	if len(keys) == 0 {
+		return errors.New("at least one issue key is required (e.g. jiractl sprints remove PROJ-1 PROJ-2)")
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	if !*dryRun {
+		if err := moveIssuesToBacklog(cfg, keys); err != nil {
+			return err
+		}
+	}
+
+	result := SprintMoveResult{
+		Target: "backlog",
+		Issues: keys,
+		DryRun: *dryRun,
+	}
+
+	if *jsonOut {
+		return printJSON(result)
+	}
+
+	if *dryRun {
+		fmt.Printf("Would move %d issue(s) to the backlog: %s\n", len(keys), strings.Join(keys, ", "))
+		return nil
+	}
+	fmt.Printf("Moved %d issue(s) to the backlog: %s\n", len(keys), strings.Join(keys, ", "))
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Rank command
+// ---------------------------------------------------------------------------
+
+func runIssuesRank(args []string) error {
+	fs := flag.NewFlagSet("issues rank", flag.ContinueOnError)
+	before := fs.String("before", "", "rank the issues directly before this issue")
+	after := fs.String("after", "", "rank the issues directly after this issue")
+	dryRun := fs.Bool("dry-run", false, "show what would change without calling Jira")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	keys := issueKeysArg(positional)
+	if len(keys) == 0 {
+		return errors.New("issue key is required (e.g. jiractl issues rank PROJ-1 --before PROJ-2)")
+	}
+	if (*before == "") == (*after == "") {
+		return errors.New("exactly one of --before or --after is required")
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	result := RankResult{
+		Issues: keys,
+		Before: strings.ToUpper(*before),
+		After:  strings.ToUpper(*after),
+		DryRun: *dryRun,
+		URL:    cfg.Server + "/browse/" + keys[0],
+	}
+
+	if !*dryRun {
+		if err := rankIssues(cfg, keys, result.Before, result.After); err != nil {
+			return err
+		}
+	}
+
+	if *jsonOut {
+		return printJSON(result)
+	}
+
+	verb := "Ranked"
+	if *dryRun {
+		verb = "Would rank"
+	}
+	if result.Before != "" {
+		fmt.Printf("%s %s before %s\n", verb, strings.Join(keys, ", "), result.Before)
+	} else {
+		fmt.Printf("%s %s after %s\n", verb, strings.Join(keys, ", "), result.After)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Jira Agile API calls
+// ---------------------------------------------------------------------------
+
+// getPagedValues pages through a startAt/maxResults list endpoint until limit
+// values are collected or the server reports the last page.
+func getPagedValues[T any](cfg Config, path string, query url.Values, limit int) ([]T, bool, error) {
+	var all []T
+	startAt := 0
+	hasMore := false
+
+	for len(all) < limit {
+		q := url.Values{}
+		for k, v := range query {
+			q[k] = v
+		}
+		q.Set("startAt", strconv.Itoa(startAt))
+		q.Set("maxResults", strconv.Itoa(minInt(limit-len(all), 50)))
+
+		var page JiraPage[T]
+		if err := jiraDo(cfg, http.MethodGet, path, q, nil, &page); err != nil {
+			return nil, false, err
+		}
+
+		all = append(all, page.Values...)
+		startAt += len(page.Values)
+		hasMore = !page.IsLast
+
+		if len(page.Values) == 0 || page.IsLast {
+			break
+		}
+	}
+
+	if len(all) > limit {
+		all = all[:limit]
+	}
+	return all, hasMore, nil
+}
+
+func getSprint(cfg Config, sprintID int) (JiraSprint, error) {
+	var sprint JiraSprint
+	err := jiraDo(cfg, http.MethodGet, fmt.Sprintf("/rest/agile/1.0/sprint/%d", sprintID), nil, nil, &sprint)
+	return sprint, err
+}
+
+// getSprintIssues mirrors searchIssues for the sprint issue endpoint, which
+// paginates with startAt instead of page tokens.
+func getSprintIssues(cfg Config, sprintID int, limit int) (SearchIssuesResult, error) {
+	result := SearchIssuesResult{}
+	var all []JiraIssue
+	path := fmt.Sprintf("/rest/agile/1.0/sprint/%d/issue", sprintID)
+
+	for len(all) < limit {
+		q := url.Values{}
+		q.Set("startAt", strconv.Itoa(len(all)))
+		q.Set("maxResults", strconv.Itoa(minInt(limit-len(all), 100)))
+		q.Set("fields", issueSearchFields)
+
+		var page JiraSprintIssuesResponse
+		if err := jiraDo(cfg, http.MethodGet, path, q, nil, &page); err != nil {
+			return result, err
+		}
+		result.Total = page.Total
+
+		all = append(all, page.Issues...)
+		if len(page.Issues) == 0 || len(all) >= page.Total {
+			break
+		}
+	}
+
+	if len(all) > limit {
+		all = all[:limit]
+	}
+	result.Issues = all
+	result.HasMore = len(all) < result.Total
+	return result, nil
+}
+
+// agileMoveBatch is the maximum number of issues the agile move and rank
+// endpoints accept per request.
+const agileMoveBatch = 50
+
+func moveIssuesToSprint(cfg Config, sprintID int, keys []string) error {
+	path := fmt.Sprintf("/rest/agile/1.0/sprint/%d/issue", sprintID)
+	for start := 0; start < len(keys); start += agileMoveBatch {
+		batch := keys[start:minInt(start+agileMoveBatch, len(keys))]
+		if err := jiraDo(cfg, http.MethodPost, path, nil, JiraMoveIssuesRequest{Issues: batch}, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func moveIssuesToBacklog(cfg Config, keys []string) error {
+	for start := 0; start < len(keys); start += agileMoveBatch {
+		batch := keys[start:minInt(start+agileMoveBatch, len(keys))]
+		if err := jiraDo(cfg, http.MethodPost, "/rest/agile/1.0/backlog/issue", nil, JiraMoveIssuesRequest{Issues: batch}, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func rankIssues(cfg Config, keys []string, before, after string) error {
+	if len(keys) > agileMoveBatch {
+		return fmt.Errorf("at most %d issues can be ranked at once", agileMoveBatch)
+	}
+	body := JiraRankRequest{
+		Issues:          keys,
+		RankBeforeIssue: before,
+		RankAfterIssue:  after,
+	}
+
+	var resp JiraRankResponse
+	if err := jiraDo(cfg, http.MethodPut, "/rest/agile/1.0/issue/rank", nil, body, &resp); err != nil {
+		return err
+	}
+
+	var failures []string
+	for _, e := range resp.Entries {
+		if e.Status >= 300 {
+			failures = append(failures, fmt.Sprintf("%s: %s", e.IssueKey, strings.Join(e.Errors, "; ")))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("rank failed for %s", strings.Join(failures, ", "))
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Agile helpers
+// ---------------------------------------------------------------------------
+
+func boardToView(b JiraBoard, server string) BoardView {
+	v := BoardView{
+		ID:   b.ID,
+		Name: b.Name,
+		Type: b.Type,
+		URL:  fmt.Sprintf("%s/secure/RapidBoard.jspa?rapidView=%d", server, b.ID),
+	}
+	if b.Location != nil {
+		v.Project = b.Location.ProjectKey
+	}
+	return v
+}
+
+func sprintToView(s JiraSprint) SprintView {
+	return SprintView{
+		ID:        s.ID,
+		Name:      s.Name,
+		State:     s.State,
+		Goal:      s.Goal,
+		Start:     formatDate(s.StartDate),
+		End:       formatDate(s.EndDate),
+		Completed: formatDate(s.CompleteDate),
+		BoardID:   s.OriginBoardID,
+	}
+}
+
+func sprintIDArg(args []string, example string) (int, error) {
+	if len(args) == 0 {
+		return 0, fmt.Errorf("sprint ID is required (e.g. %s)", example)
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid sprint ID %q", args[0])
+	}
+	return id, nil
+}
+
+// issueKeysArg upper-cases and de-duplicates issue keys, preserving order.
+func issueKeysArg(args []string) []string {
+	seen := make(map[string]bool, len(args))
+	keys := make([]string, 0, len(args))
+	for _, a := range args {
+		for _, k := range strings.Split(a, ",") {
+			k = strings.ToUpper(strings.TrimSpace(k))
+			if k == "" || seen[k] {
+				continue
+			}
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// sprintClause turns a --sprint value into a JQL clause: "current" maps to
+// the open sprints, numbers to sprint IDs and anything else to a sprint name.
+func sprintClause(sprint string) string {
+	s := strings.TrimSpace(sprint)
+	switch strings.ToLower(s) {
+	case "current", "active", "open":
+		return "sprint in openSprints()"
+	case "future", "next":
+		return "sprint in futureSprints()"
+	}
+	if _, err := strconv.Atoi(s); err == nil {
+		return "sprint = " + s
+	}
+	return fmt.Sprintf("sprint = %q", s)
+}
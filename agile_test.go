@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetSprintIssuesPaginatesWithStartAt(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/agile/1.0/sprint/7/issue", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("startAt") {
+		case "0":
+			writeJSON(t, w, JiraSprintIssuesResponse{
+				Total:  3,
+				Issues: []JiraIssue{{Key: "PROJ-1"}, {Key: "PROJ-2"}},
+			})
+		case "2":
+			writeJSON(t, w, JiraSprintIssuesResponse{
+				StartAt: 2,
+				Total:   3,
+				Issues:  []JiraIssue{{Key: "PROJ-3"}},
+			})
+		default:
+			t.Fatalf("unexpected startAt %q", r.URL.Query().Get("startAt"))
+		}
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	result, err := getSprintIssues(cfg, 7, 10)
+	if err != nil {
+		t.Fatalf("getSprintIssues returned error: %v", err)
+	}
+	if got := len(result.Issues); got != 3 {
+		t.Fatalf("expected 3 issues, got %d", got)
+	}
+	if result.HasMore {
+		t.Fatal("expected has_more=false once all issues are fetched")
+	}
+}
+
+func TestRankIssuesReportsMultiStatusFailures(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/agile/1.0/issue/rank", func(w http.ResponseWriter, r *http.Request) {
+		var body JiraRankRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode rank request: %v", err)
+		}
+		if body.RankBeforeIssue != "PROJ-9" {
+			t.Fatalf("expected rankBeforeIssue=PROJ-9, got %q", body.RankBeforeIssue)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusMultiStatus)
+		_ = json.NewEncoder(w).Encode(JiraRankResponse{Entries: []JiraRankEntry{
+			{IssueKey: "PROJ-1", Status: 200},
+			{IssueKey: "PROJ-2", Status: 400, Errors: []string{"Issue is not on a board"}},
+		}})
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	err := rankIssues(cfg, []string{"PROJ-1", "PROJ-2"}, "PROJ-9", "")
+	if err == nil {
+		t.Fatal("expected error for partially failed rank")
+	}
+	if !containsAll(err.Error(), []string{"PROJ-2", "not on a board"}) {
+		t.Fatalf("expected failing issue in error, got %q", err.Error())
+	}
+}
+
+func TestSprintClause(t *testing.T) {
+	cases := map[string]string{
+		"current":   "sprint in openSprints()",
+		"42":        "sprint = 42",
+		"Sprint 12": `sprint = "Sprint 12"`,
+	}
+	for in, want := range cases {
+		if got := sprintClause(in); got != want {
+			t.Fatalf("sprintClause(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,481 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// attachmentHTTPTimeout bounds a whole download or upload, which can take far
+// longer than an ordinary API call.
+const attachmentHTTPTimeout = 10 * time.Minute
+
+// ---------------------------------------------------------------------------
+// Jira API response types
+// ---------------------------------------------------------------------------
+
+type JiraAttachment struct {
+	ID       string    `json:"id"`
+	Filename string    `json:"filename"`
+	Author   *JiraUser `json:"author"`
+	Created  string    `json:"created"`
+	Size     int64     `json:"size"`
+	MimeType string    `json:"mimeType"`
+	Content  string    `json:"content"`
+}
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type AttachmentView struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+	Size     int64  `json:"size"`
+	MimeType string `json:"mime_type"`
+	Author   string `json:"author"`
+	Created  string `json:"created"`
+	URL      string `json:"url"`
+}
+
+type AttachmentDownloadResult struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	Status   string `json:"status"`
+}
+
+type AttachmentUploadResult struct {
+	Key         string           `json:"key"`
+	Attachments []AttachmentView `json:"attachments"`
+	DryRun      bool             `json:"dry_run,omitempty"`
+}
+
+// ---------------------------------------------------------------------------
+// Help functions
+// ---------------------------------------------------------------------------
+
+func printAttachmentsHelp() {
+	fmt.Println("jiractl attachments commands:")
+	fmt.Println("  attachments list      ISSUE-KEY [--json]")
+	fmt.Println("  attachments download  ISSUE-KEY [--name GLOB] [--dir DIR] [--overwrite] [--json]")
+	fmt.Println("  attachments add       ISSUE-KEY FILE... [--dry-run] [--json]")
+}
+
+// ---------------------------------------------------------------------------
+// Attachment commands
+// ---------------------------------------------------------------------------
+
+func runAttachments(args []string) error {
+	if len(args) == 0 {
+		printAttachmentsHelp()
+		return nil
+	}
+
+	switch args[0] {
+	case "list":
+		return runAttachmentsList(args[1:])
+	case "download":
+		return runAttachmentsDownload(args[1:])
+	case "add":
+		return runAttachmentsAdd(args[1:])
+	case "help", "--help", "-h":
+		printAttachmentsHelp()
+		return nil
+	default:
+		printAttachmentsHelp()
+		return fmt.Errorf("unknown attachments command %q", args[0])
+	}
+}
+
+func runAttachmentsList(args []string) error {
+	fs := flag.NewFlagSet("attachments list", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("issue key is required (e.g. jiractl attachments list PROJ-123)")
+	}
+	issueKey := strings.ToUpper(positional[0])
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	attachments, err := getAttachments(cfg, issueKey)
+	if err != nil {
+		return err
+	}
+	views := attachmentsToViews(attachments)
+
+	if *jsonOut {
+		return printJSON(map[string]any{"key": issueKey, "attachments": views})
+	}
+
+	if len(views) == 0 {
+		fmt.Printf("No attachments on %s.\n", issueKey)
+		return nil
+	}
+	fmt.Printf("Attachments on %s (%d):\n", issueKey, len(views))
+	printAttachmentViews(views)
+	return nil
+}
+
+func runAttachmentsDownload(args []string) error {
+	fs := flag.NewFlagSet("attachments download", flag.ContinueOnError)
+	name := fs.String("name", "", "only download attachments whose filename matches this glob, e.g. \"*.log\"")
+	dir := fs.String("dir", ".", "directory to write files to")
+	overwrite := fs.Bool("overwrite", false, "replace existing files instead of skipping them")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("issue key is required (e.g. jiractl attachments download PROJ-123)")
+	}
+	issueKey := strings.ToUpper(positional[0])
+	if *name != "" {
+		if _, err := path.Match(*name, ""); err != nil {
+			return fmt.Errorf("invalid --name pattern %q: %w", *name, err)
+		}
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	attachments, err := getAttachments(cfg, issueKey)
+	if err != nil {
+		return err
+	}
+	selected := filterAttachments(attachments, *name)
+	if len(selected) == 0 {
+		if *name != "" {
+			return fmt.Errorf("no attachments on %s match %q", issueKey, *name)
+		}
+		return fmt.Errorf("no attachments on %s", issueKey)
+	}
+
+	if err := os.MkdirAll(*dir, 0o755); err != nil {
+		return err
+	}
+
+	results, err := downloadAttachments(cfg, selected, *dir, *overwrite)
+	if *jsonOut {
+		if err != nil {
+			return err
+		}
+		return printJSON(map[string]any{"key": issueKey, "files": results})
+	}
+
+	for _, r := range results {
+		switch r.Status {
+		case "skipped":
+			fmt.Printf("Skipped %s (exists; use --overwrite)\n", r.Path)
+		default:
+			fmt.Printf("Downloaded %s (%s)\n", r.Path, formatBytes(r.Size))
+		}
+	}
+	return err
+}
+
+func runAttachmentsAdd(args []string) error {
+	fs := flag.NewFlagSet("attachments add", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "check the files without uploading them")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 2 {
+		return errors.New("usage: jiractl attachments add PROJ-123 FILE...")
+	}
+	issueKey := strings.ToUpper(positional[0])
+	files := positional[1:]
+
+	// Check every file up front so a typo doesn't leave a partial upload.
+	var planned []AttachmentView
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return fmt.Errorf("%s is a directory", f)
+		}
+		planned = append(planned, AttachmentView{Filename: filepath.Base(f), Size: info.Size()})
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	result := AttachmentUploadResult{Key: issueKey, Attachments: planned, DryRun: *dryRun}
+	if !*dryRun {
+		uploaded, err := uploadAttachments(cfg, issueKey, files)
+		if err != nil {
+			return err
+		}
+		result.Attachments = attachmentsToViews(uploaded)
+	}
+
+	if *jsonOut {
+		return printJSON(result)
+	}
+
+	verb := "Attached"
+	if *dryRun {
+		verb = "Would attach"
+	}
+	for _, a := range result.Attachments {
+		fmt.Printf("%s %s (%s) to %s\n", verb, a.Filename, formatBytes(a.Size), issueKey)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Jira API calls
+// ---------------------------------------------------------------------------
+
+func getAttachments(cfg Config, issueKey string) ([]JiraAttachment, error) {
+	q := url.Values{}
+	q.Set("fields", "attachment")
+	var issue JiraIssue
+	if err := jiraDo(cfg, http.MethodGet, "/rest/api/3/issue/"+url.PathEscape(issueKey), q, nil, &issue); err != nil {
+		return nil, err
+	}
+	return issue.Fields.Attachments, nil
+}
+
+// downloadAttachments writes each attachment into dir. It stops at the first
+// failure and returns the results so far along with the error.
+func downloadAttachments(cfg Config, attachments []JiraAttachment, dir string, overwrite bool) ([]AttachmentDownloadResult, error) {
+	client := attachmentHTTPClient(cfg)
+
+	// Two attachments may share a filename; later ones get their ID prefixed.
+	used := map[string]bool{}
+	var results []AttachmentDownloadResult
+	for _, a := range attachments {
+		filename := safeFilename(a.Filename, a.ID)
+		if used[filename] {
+			filename = a.ID + "-" + filename
+		}
+		used[filename] = true
+		target := filepath.Join(dir, filename)
+
+		result := AttachmentDownloadResult{ID: a.ID, Filename: a.Filename, Path: target, Size: a.Size}
+		if _, err := os.Stat(target); err == nil && !overwrite {
+			result.Status = "skipped"
+			results = append(results, result)
+			continue
+		}
+
+		n, err := downloadAttachment(client, cfg, a, target)
+		if err != nil {
+			return results, fmt.Errorf("failed to download %s: %w", a.Filename, err)
+		}
+		result.Size = n
+		result.Status = "downloaded"
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// downloadAttachment streams one attachment to a temporary file next to
+// target and renames it into place once the size matches the metadata, so an
+// interrupted download never leaves a truncated file behind.
+func downloadAttachment(client *http.Client, cfg Config, a JiraAttachment, target string) (int64, error) {
+	u := a.Content
+	if u == "" {
+		u = cfg.Server + "/rest/api/3/attachment/content/" + url.PathEscape(a.ID)
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("jira api request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return 0, apiError(resp)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".jiractl-download-*")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := io.Copy(tmp, resp.Body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return n, err
+	}
+	if a.Size > 0 && n != a.Size {
+		return n, fmt.Errorf("size mismatch: got %d bytes, expected %d", n, a.Size)
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		return n, err
+	}
+	return n, nil
+}
+
+// uploadAttachments sends all files in one multipart request. The body is
+// streamed through a pipe so large files are never held in memory.
+func uploadAttachments(cfg Config, issueKey string, files []string) ([]JiraAttachment, error) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		for _, f := range files {
+			if err := copyFilePart(mw, f); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+		pw.CloseWithError(mw.Close())
+	}()
+
+	u := cfg.Server + "/rest/api/3/issue/" + url.PathEscape(issueKey) + "/attachments"
+	req, err := http.NewRequest(http.MethodPost, u, pr)
+	if err != nil {
+		pr.Close()
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	// Jira rejects attachment uploads without this XSRF opt-out.
+	req.Header.Set("X-Atlassian-Token", "no-check")
+
+	resp, err := attachmentHTTPClient(cfg).Do(req)
+	if err != nil {
+		pr.Close()
+		return nil, fmt.Errorf("jira api request failed: %w", err)
+	}
+	pr.Close()
+
+	var uploaded []JiraAttachment
+	if err := decodeAPIResponse(resp, &uploaded); err != nil {
+		return nil, err
+	}
+	return uploaded, nil
+}
+
+func copyFilePart(mw *multipart.Writer, name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	part, err := mw.CreateFormFile("file", filepath.Base(name))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, f)
+	return err
+}
+
+// attachmentHTTPClient is a separate client with a longer timeout for file
+// transfers. Credentials are only sent to the Jira host, not to the media
+// service that content downloads redirect to.
+func attachmentHTTPClient(cfg Config) *http.Client {
+	host := ""
+	if u, err := url.Parse(cfg.Server); err == nil {
+		host = u.Host
+	}
+	return &http.Client{
+		Timeout: attachmentHTTPTimeout,
+		Transport: &basicAuthTransport{
+			email: cfg.Email,
+			token: cfg.APIToken,
+			host:  host,
+			base:  http.DefaultTransport,
+		},
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Attachment helpers
+// ---------------------------------------------------------------------------
+
+func attachmentsToViews(attachments []JiraAttachment) []AttachmentView {
+	var views []AttachmentView
+	for _, a := range attachments {
+		views = append(views, AttachmentView{
+			ID:       a.ID,
+			Filename: a.Filename,
+			Size:     a.Size,
+			MimeType: a.MimeType,
+			Author:   userDisplayName(a.Author),
+			Created:  formatDate(a.Created),
+			URL:      a.Content,
+		})
+	}
+	return views
+}
+
+func printAttachmentViews(views []AttachmentView) {
+	for _, a := range views {
+		fmt.Printf("  %-8s %-40s %10s  %s  %s\n", a.ID, a.Filename, formatBytes(a.Size), a.Created, a.Author)
+	}
+}
+
+// filterAttachments keeps attachments whose filename matches a glob; an empty
+// pattern keeps everything. Matching is case-insensitive and ignores any
+// directory part of the name.
+func filterAttachments(attachments []JiraAttachment, pattern string) []JiraAttachment {
+	if pattern == "" {
+		return attachments
+	}
+	pattern = strings.ToLower(pattern)
+	var out []JiraAttachment
+	for _, a := range attachments {
+		if ok, _ := path.Match(pattern, strings.ToLower(safeFilename(a.Filename, a.ID))); ok {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// safeFilename strips any directory components from a server-supplied name.
+func safeFilename(name, fallback string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == ".." || name == "/" || name == "" {
+		return "attachment-" + fallback
+	}
+	return name
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDownloadAttachmentsFiltersAndChecksSize(t *testing.T) {
+	var ts *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/PROJ-1", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("fields"); got != "attachment" {
+			t.Fatalf("expected fields=attachment, got %q", got)
+		}
+		writeJSON(t, w, map[string]any{
+			"key": "PROJ-1",
+			"fields": map[string]any{
+				"attachment": []map[string]any{
+					{"id": "1", "filename": "build.log", "size": 5, "content": ts.URL + "/content/1"},
+					{"id": "2", "filename": "screen.png", "size": 3, "content": ts.URL + "/content/2"},
+					{"id": "3", "filename": "../../truncated.LOG", "size": 10, "content": ts.URL + "/content/3"},
+				},
+			},
+		})
+	})
+	mux.HandleFunc("/content/", func(w http.ResponseWriter, r *http.Request) {
+		if _, _, ok := r.BasicAuth(); !ok {
+			t.Fatalf("expected basic auth on content request")
+		}
+		_, _ = io.WriteString(w, "hello")
+	})
+	ts = httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	attachments, err := getAttachments(cfg, "PROJ-1")
+	if err != nil {
+		t.Fatalf("getAttachments returned error: %v", err)
+	}
+	selected := filterAttachments(attachments, "*.log")
+	if len(selected) != 2 {
+		t.Fatalf("expected 2 attachments matching *.log, got %d", len(selected))
+	}
+
+	dir := t.TempDir()
+	results, err := downloadAttachments(cfg, selected, dir, false)
+	if err == nil || !strings.Contains(err.Error(), "size mismatch") {
+		t.Fatalf("expected size mismatch error, got %v", err)
+	}
+	if len(results) != 1 || results[0].Status != "downloaded" {
+		t.Fatalf("expected the first download to succeed, got %+v", results)
+	}
+	if b, err := os.ReadFile(filepath.Join(dir, "build.log")); err != nil || string(b) != "hello" {
+		t.Fatalf("unexpected build.log content %q (err %v)", b, err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Fatalf("expected no partial or escaped files, got %d entries", len(entries))
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(dir), "truncated.LOG")); err == nil {
+		t.Fatalf("download escaped the target directory")
+	}
+}
+
+func TestUploadAttachmentsSendsMultipartWithXSRFHeader(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "test.log")
+	if err := os.WriteFile(logPath, []byte("PASS\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/PROJ-9/attachments" || r.Method != http.MethodPost {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("X-Atlassian-Token"); got != "no-check" {
+			t.Fatalf("expected X-Atlassian-Token no-check, got %q", got)
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("expected multipart file part: %v", err)
+		}
+		defer file.Close()
+		body, _ := io.ReadAll(file)
+		writeJSON(t, w, []map[string]any{
+			{"id": "42", "filename": header.Filename, "size": len(body)},
+		})
+	}))
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	uploaded, err := uploadAttachments(cfg, "PROJ-9", []string{logPath})
+	if err != nil {
+		t.Fatalf("uploadAttachments returned error: %v", err)
+	}
+	if len(uploaded) != 1 || uploaded[0].Filename != "test.log" || uploaded[0].Size != 5 {
+		t.Fatalf("unexpected upload result %+v", uploaded)
+	}
+}
@@ -0,0 +1,299 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// maxBatchLine is the longest operation line accepted (descriptions can be
+// long).
+const maxBatchLine = 4 << 20
+
+// ---------------------------------------------------------------------------
+// Batch operation types
+// ---------------------------------------------------------------------------
+
+// BatchOp is one NDJSON operation line. Which fields apply depends on Op;
+// unknown fields are rejected so typos don't silently do nothing.
+type BatchOp struct {
+	ID  json.RawMessage `json:"id,omitempty"`
+	Op  string          `json:"op"`
+	Key string          `json:"key,omitempty"`
+
+	// comment
+	Body string `json:"body,omitempty"`
+	// transition (with optional screen fields and comment), worklog comment
+	Status     string            `json:"status,omitempty"`
+	Resolution string            `json:"resolution,omitempty"`
+	Fields     map[string]string `json:"fields,omitempty"`
+	Comment    string            `json:"comment,omitempty"`
+	// assign ("me", an email or a name; empty means the reporter)
+	Email string `json:"email,omitempty"`
+	// watch, unwatch
+	Users []string `json:"users,omitempty"`
+	// label, component
+	Add    []string `json:"add,omitempty"`
+	Remove []string `json:"remove,omitempty"`
+	// link
+	Relation string `json:"relation,omitempty"`
+	To       string `json:"to,omitempty"`
+	// worklog
+	Time    string `json:"time,omitempty"`
+	Started string `json:"started,omitempty"`
+	// create, set-parent (parent)
+	IssueCreateInput
+}
+
+// BatchResponse is written for every operation line, in input order.
+type BatchResponse struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Line   int             `json:"line"`
+	Op     string          `json:"op,omitempty"`
+	Key    string          `json:"key,omitempty"`
+	OK     bool            `json:"ok"`
+	Result any             `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+	// Code and Details are set for structured errors such as MISSING_FIELDS.
+	Code    string `json:"code,omitempty"`
+	Details any    `json:"details,omitempty"`
+}
+
+// batchOps lists the supported operations for help and error messages.
+var batchOps = []string{"assign", "comment", "component", "create", "label", "link", "set-parent", "transition", "unwatch", "watch", "worklog"}
+
+// ---------------------------------------------------------------------------
+// Help functions
+// ---------------------------------------------------------------------------
+
+func printBatchHelp() {
+	fmt.Println("jiractl batch commands:")
+	fmt.Println("  batch run  FILE.ndjson|- [--stop-on-error] [--dry-run]")
+	fmt.Println()
+	fmt.Println("Each line is one operation, e.g.:")
+	fmt.Println(`  {"id":1,"op":"comment","key":"PROJ-1","body":"Deployed"}`)
+	fmt.Println(`  {"id":2,"op":"transition","key":"PROJ-1","status":"Done","comment":"Fixed"}`)
+	fmt.Println("ops: " + strings.Join(batchOps, ", "))
+}
+
+// ---------------------------------------------------------------------------
+// Batch commands
+// ---------------------------------------------------------------------------
+
+func runBatch(args []string) error {
+	if len(args) == 0 {
+		printBatchHelp()
+		return nil
+	}
+
+	switch args[0] {
+	case "run":
+		return runBatchRun(args[1:])
+	case "help", "--help", "-h":
+		printBatchHelp()
+		return nil
+	default:
+		printBatchHelp()
+		return fmt.Errorf("unknown batch command %q", args[0])
+	}
+}
+
+func runBatchRun(args []string) error {
+	fs := flag.NewFlagSet("batch run", flag.ContinueOnError)
+	stopOnError := fs.Bool("stop-on-error", false, "stop at the first failed operation")
+	dryRun := fs.Bool("dry-run", false, "validate and resolve operations without changing anything")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("operations file is required (e.g. jiractl batch run ops.ndjson, or - for stdin)")
+	}
+
+	var in io.Reader = os.Stdin
+	if positional[0] != "-" {
+		f, err := os.Open(positional[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	total, failed, err := executeBatch(cfg, in, os.Stdout, *stopOnError, *dryRun)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "batch: %d operations, %d succeeded, %d failed\n", total, total-failed, failed)
+	if failed > 0 {
+		if *stopOnError {
+			return errors.New("batch stopped at the first failed operation")
+		}
+		return fmt.Errorf("%d of %d operations failed", failed, total)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Batch helpers
+// ---------------------------------------------------------------------------
+
+// executeBatch runs each operation line in order and writes one response
+// line per operation. Blank lines and lines starting with # are skipped.
+func executeBatch(cfg Config, in io.Reader, out io.Writer, stopOnError, dryRun bool) (int, int, error) {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), maxBatchLine)
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+
+	total, failed, line := 0, 0, 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		total++
+
+		resp := BatchResponse{Line: line}
+		var op BatchOp
+		dec := json.NewDecoder(bytes.NewReader([]byte(text)))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&op); err != nil {
+			// Still echo id/op/key when the line is valid JSON, so the
+			// caller can correlate the error.
+			var ref struct {
+				ID  json.RawMessage `json:"id"`
+				Op  string          `json:"op"`
+				Key string          `json:"key"`
+			}
+			if json.Unmarshal([]byte(text), &ref) == nil {
+				resp.ID, resp.Op, resp.Key = ref.ID, ref.Op, strings.ToUpper(ref.Key)
+			}
+			resp.Error = fmt.Sprintf("invalid operation: %v", err)
+		} else {
+			resp.ID, resp.Op = op.ID, op.Op
+			resp.Key = strings.ToUpper(strings.TrimSpace(op.Key))
+			result, err := executeBatchOp(cfg, op, dryRun)
+			if err != nil {
+				resp.Error = err.Error()
+				var ce codedError
+				if errors.As(err, &ce) {
+					resp.Code, resp.Details = ce.Code(), ce.Details()
+				}
+			} else {
+				resp.OK = true
+				resp.Result = result
+			}
+		}
+
+		if err := enc.Encode(resp); err != nil {
+			return total, failed, err
+		}
+		if !resp.OK {
+			failed++
+			if stopOnError {
+				break
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return total, failed, fmt.Errorf("failed to read operations: %w", err)
+	}
+	return total, failed, nil
+}
+
+// executeBatchOp runs one operation through the same functions as the
+// matching CLI command.
+func executeBatchOp(cfg Config, op BatchOp, dryRun bool) (any, error) {
+	if op.Op == "" {
+		return nil, errors.New("op is required")
+	}
+	key := strings.ToUpper(strings.TrimSpace(op.Key))
+	if key == "" && op.Op != "create" {
+		return nil, errors.New("key is required")
+	}
+
+	switch op.Op {
+	case "comment":
+		if op.Body == "" {
+			return nil, errors.New("body is required")
+		}
+		return commentOnIssue(cfg, key, op.Body, dryRun)
+
+	case "transition":
+		if op.Status == "" {
+			return nil, errors.New("status is required")
+		}
+		opts := TransitionOptions{Resolution: op.Resolution, Fields: op.Fields, Comment: op.Comment}
+		return transitionIssue(cfg, key, op.Status, opts, dryRun)
+
+	case "assign":
+		var user *JiraUser
+		if op.Email != "" {
+			u, err := resolveUser(cfg, op.Email)
+			if err != nil {
+				return nil, err
+			}
+			user = &u
+		}
+		return assignIssueTo(cfg, key, user, dryRun)
+
+	case "label", "component":
+		field := op.Op + "s"
+		if err := validateValueOps(field, op.Add, op.Remove); err != nil {
+			return nil, err
+		}
+		return updateIssueValues(cfg, key, field, op.Add, op.Remove, dryRun)
+
+	case "watch", "unwatch":
+		return changeWatchers(cfg, key, op.Users, op.Op == "watch", dryRun)
+
+	case "link":
+		if op.Relation == "" || op.To == "" {
+			return nil, errors.New("relation and to are required (e.g. \"relation\":\"blocks\",\"to\":\"PROJ-2\")")
+		}
+		return linkIssues(cfg, key, op.Relation, strings.ToUpper(strings.TrimSpace(op.To)), dryRun)
+
+	case "worklog":
+		if op.Time == "" {
+			return nil, errors.New("time is required (e.g. \"time\":\"1h30m\")")
+		}
+		seconds, err := parseWorkDuration(op.Time)
+		if err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		start := now.Add(-time.Duration(seconds) * time.Second)
+		if op.Started != "" {
+			if start, err = parseLocalTime(op.Started, now); err != nil {
+				return nil, err
+			}
+		}
+		return logWork(cfg, key, start, seconds, op.Comment, dryRun)
+
+	case "set-parent":
+		return setIssueParent(cfg, key, op.Parent, dryRun)
+
+	case "create":
+		if key != "" {
+			return nil, errors.New("create does not take a key; use project and/or parent")
+		}
+		return createIssueFrom(cfg, op.IssueCreateInput, dryRun)
+
+	default:
+		return nil, fmt.Errorf("unknown op %q; supported: %s", op.Op, strings.Join(batchOps, ", "))
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExecuteBatchCorrelatesResponsesAndStopsOnError(t *testing.T) {
+	var comments []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/PROJ-1/comment", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Body any `json:"body"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode comment: %v", err)
+		}
+		comments = append(comments, adfToText(req.Body))
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(t, w, map[string]any{"id": "100"})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	ops := strings.Join([]string{
+		`{"id":"a","op":"comment","key":"proj-1","body":"first"}`,
+		``,
+		`# comments are skipped`,
+		`{"id":7,"op":"comment","key":"PROJ-1","bdy":"typo"}`,
+		`{"id":"c","op":"comment","key":"PROJ-1","body":"never sent"}`,
+	}, "\n")
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	var out bytes.Buffer
+	total, failed, err := executeBatch(cfg, strings.NewReader(ops), &out, true, false)
+	if err != nil {
+		t.Fatalf("executeBatch returned error: %v", err)
+	}
+	if total != 2 || failed != 1 {
+		t.Fatalf("expected 2 ops run with 1 failure, got total=%d failed=%d", total, failed)
+	}
+	if len(comments) != 1 || comments[0] != "first" {
+		t.Fatalf("unexpected comments posted: %v", comments)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 response lines, got %d: %s", len(lines), out.String())
+	}
+	var first, second BatchResponse
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatal(err)
+	}
+	if string(first.ID) != `"a"` || !first.OK || first.Key != "PROJ-1" || first.Line != 1 {
+		t.Fatalf("unexpected first response %+v", first)
+	}
+	if string(second.ID) != "7" || second.OK || second.Line != 4 || !strings.Contains(second.Error, "bdy") {
+		t.Fatalf("unexpected second response %+v", second)
+	}
+}
@@ -0,0 +1,321 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type BulkItemResult struct {
+	Key    string `json:"key"`
+	OK     bool   `json:"ok"`
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BulkSummary struct {
+	Operation string `json:"operation"`
+	JQL       string `json:"jql"`
+	Total     int    `json:"total"`
+	Succeeded int    `json:"succeeded"`
+	Failed    int    `json:"failed"`
+	HasMore   bool   `json:"has_more,omitempty"`
+	DryRun    bool   `json:"dry_run,omitempty"`
+}
+
+type BulkResult struct {
+	Summary BulkSummary      `json:"summary"`
+	Results []BulkItemResult `json:"results"`
+}
+
+// bulkOptions are the flags shared by every bulk command.
+type bulkOptions struct {
+	jql         *string
+	limit       *int
+	concurrency *int
+	dryRun      *bool
+	jsonOut     *bool
+}
+
+// ---------------------------------------------------------------------------
+// Help functions
+// ---------------------------------------------------------------------------
+
+func printBulkHelp() {
+	fmt.Println("jiractl bulk commands:")
+	fmt.Println("  bulk transition  --jql \"...\" --status STATUS [--resolution NAME] [--field NAME=VALUE]... [--comment TEXT] [options]")
+	fmt.Println("  bulk assign      --jql \"...\" [--email EMAIL|me] [options]")
+	fmt.Println("  bulk label       --jql \"...\" [--add L] [--remove L] [options]")
+	fmt.Println("  bulk comment     --jql \"...\" --body TEXT [options]")
+	fmt.Println()
+	fmt.Println("options: [--limit N] [--concurrency 4] [--dry-run] [--json]")
+}
+
+// ---------------------------------------------------------------------------
+// Bulk commands
+// ---------------------------------------------------------------------------
+
+func runBulk(args []string) error {
+	if len(args) == 0 {
+		printBulkHelp()
+		return nil
+	}
+
+	switch args[0] {
+	case "transition":
+		return runBulkTransition(args[1:])
+	case "assign":
+		return runBulkAssign(args[1:])
+	case "label":
+		return runBulkLabel(args[1:])
+	case "comment":
+		return runBulkComment(args[1:])
+	case "help", "--help", "-h":
+		printBulkHelp()
+		return nil
+	default:
+		printBulkHelp()
+		return fmt.Errorf("unknown bulk command %q", args[0])
+	}
+}
+
+func runBulkTransition(args []string) error {
+	fs := flag.NewFlagSet("bulk transition", flag.ContinueOnError)
+	opts := addBulkFlags(fs)
+	status := fs.String("status", "", "target status name (required)")
+	resolution := fs.String("resolution", "", "resolution to set, e.g. Fixed")
+	var fieldArgs rawList
+	fs.Var(&fieldArgs, "field", "screen field as NAME=VALUE (repeatable)")
+	comment := fs.String("comment", "", "comment to add with each transition")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *status == "" {
+		return errors.New("--status is required (e.g. --status Done)")
+	}
+	fieldValues, err := parseFieldAssignments(fieldArgs)
+	if err != nil {
+		return err
+	}
+	transitionOpts := TransitionOptions{Resolution: *resolution, Fields: fieldValues, Comment: *comment, MatchTargets: true}
+
+	return executeBulk("transition", opts, func(cfg Config, key string, dryRun bool) (any, error) {
+		return transitionIssue(cfg, key, *status, transitionOpts, dryRun)
+	})
+}
+
+func runBulkAssign(args []string) error {
+	fs := flag.NewFlagSet("bulk assign", flag.ContinueOnError)
+	opts := addBulkFlags(fs)
+	email := fs.String("email", "", "assignee email or \"me\" (defaults to each issue's reporter)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// The assignee is resolved once, up front, rather than per issue.
+	var user *JiraUser
+	return executeBulkWithSetup("assign", opts, func(cfg Config) error {
+		if *email == "" {
+			return nil
+		}
+		u, err := resolveUser(cfg, *email)
+		if err != nil {
+			return err
+		}
+		user = &u
+		return nil
+	}, func(cfg Config, key string, dryRun bool) (any, error) {
+		return assignIssueTo(cfg, key, user, dryRun)
+	})
+}
+
+func runBulkLabel(args []string) error {
+	fs := flag.NewFlagSet("bulk label", flag.ContinueOnError)
+	opts := addBulkFlags(fs)
+	var add, remove stringList
+	fs.Var(&add, "add", "label to add (repeatable or comma-separated)")
+	fs.Var(&remove, "remove", "label to remove (repeatable or comma-separated)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := validateValueOps("labels", add, remove); err != nil {
+		return err
+	}
+
+	return executeBulk("label", opts, func(cfg Config, key string, dryRun bool) (any, error) {
+		return updateIssueValues(cfg, key, "labels", add, remove, dryRun)
+	})
+}
+
+func runBulkComment(args []string) error {
+	fs := flag.NewFlagSet("bulk comment", flag.ContinueOnError)
+	opts := addBulkFlags(fs)
+	body := fs.String("body", "", "comment text (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *body == "" {
+		return errors.New("--body is required")
+	}
+
+	return executeBulk("comment", opts, func(cfg Config, key string, dryRun bool) (any, error) {
+		return commentOnIssue(cfg, key, *body, dryRun)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// Bulk helpers
+// ---------------------------------------------------------------------------
+
+type bulkFunc func(cfg Config, key string, dryRun bool) (any, error)
+
+func addBulkFlags(fs *flag.FlagSet) bulkOptions {
+	return bulkOptions{
+		jql:         fs.String("jql", "", "JQL selecting the issues (required)"),
+		limit:       fs.Int("limit", 100, "max issues to process"),
+		concurrency: fs.Int("concurrency", 4, "issues processed in parallel"),
+		dryRun:      fs.Bool("dry-run", false, "resolve each change without applying it"),
+		jsonOut:     fs.Bool("json", false, "print JSON"),
+	}
+}
+
+func executeBulk(op string, opts bulkOptions, fn bulkFunc) error {
+	return executeBulkWithSetup(op, opts, nil, fn)
+}
+
+// executeBulkWithSetup validates the shared flags, selects issues by JQL,
+// runs setup once and then fn for every issue.
+func executeBulkWithSetup(op string, opts bulkOptions, setup func(Config) error, fn bulkFunc) error {
+	if strings.TrimSpace(*opts.jql) == "" {
+		return errors.New("--jql is required")
+	}
+	if *opts.limit <= 0 {
+		return errors.New("--limit must be greater than 0")
+	}
+	if *opts.concurrency <= 0 {
+		return errors.New("--concurrency must be greater than 0")
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+	if setup != nil {
+		if err := setup(cfg); err != nil {
+			return err
+		}
+	}
+
+	found, err := searchIssuesWithFields(cfg, *opts.jql, *opts.limit, "summary")
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(found.Issues))
+	for _, issue := range found.Issues {
+		keys = append(keys, issue.Key)
+	}
+
+	results := bulkApply(keys, *opts.concurrency, func(key string) (any, error) {
+		return fn(cfg, key, *opts.dryRun)
+	})
+
+	out := BulkResult{
+		Summary: BulkSummary{
+			Operation: op,
+			JQL:       *opts.jql,
+			Total:     len(results),
+			HasMore:   found.HasMore || found.Total > len(keys),
+			DryRun:    *opts.dryRun,
+		},
+		Results: results,
+	}
+	for _, r := range results {
+		if r.OK {
+			out.Summary.Succeeded++
+		} else {
+			out.Summary.Failed++
+		}
+	}
+
+	var failure error
+	if out.Summary.Failed > 0 {
+		failure = fmt.Errorf("%d of %d issues failed", out.Summary.Failed, out.Summary.Total)
+	}
+	if *opts.jsonOut {
+		return printPartialJSON(out, failure)
+	}
+	printBulkResult(out)
+	return failure
+}
+
+// bulkApply runs fn for every key with at most concurrency calls in flight.
+// Results keep the order of keys, and a failing issue never stops the others.
+func bulkApply(keys []string, concurrency int, fn func(key string) (any, error)) []BulkItemResult {
+	results := make([]BulkItemResult, len(keys))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < minInt(concurrency, len(keys)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result, err := fn(keys[i])
+				item := BulkItemResult{Key: keys[i], OK: err == nil, Result: result}
+				if err != nil {
+					item.Error = err.Error()
+				}
+				results[i] = item
+			}
+		}()
+	}
+	for i := range keys {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+func printBulkResult(out BulkResult) {
+	for _, r := range out.Results {
+		if !r.OK {
+			fmt.Fprintf(os.Stderr, "FAIL  %-12s %s\n", r.Key, r.Error)
+			continue
+		}
+		fmt.Printf("ok    %-12s %s\n", r.Key, bulkItemSummary(r.Result))
+	}
+
+	s := out.Summary
+	prefix := ""
+	if s.DryRun {
+		prefix = "dry run: "
+	}
+	fmt.Printf("\n%s%s: %d issues, %d succeeded, %d failed\n", prefix, s.Operation, s.Total, s.Succeeded, s.Failed)
+	if s.HasMore {
+		fmt.Println("More issues match the JQL; raise --limit to include them.")
+	}
+}
+
+func bulkItemSummary(result any) string {
+	switch r := result.(type) {
+	case TransitionResult:
+		if r.Warning != "" {
+			return "-> " + r.Status + " (" + r.Warning + ")"
+		}
+		return "-> " + r.Status
+	case AssignResult:
+		return "-> " + firstNonEmpty(r.AssigneeName, r.Assignee)
+	case ValuesResult:
+		return valueChanges(r)
+	case CommentResult:
+		return "commented"
+	}
+	return ""
+}
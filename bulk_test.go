@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBulkApplyMatchesTransitionPerIssueAndKeepsGoing(t *testing.T) {
+	workflows := map[string][]JiraTransition{
+		"OPS-1": {{ID: "31", Name: "Done"}, {ID: "11", Name: "In Progress"}},
+		"OPS-2": {{ID: "51", Name: "Resolve", To: &JiraNameField{Name: "Done"}}},
+	}
+
+	var mu sync.Mutex
+	posted := map[string]string{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		key := parts[len(parts)-2]
+		transitions, ok := workflows[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			writeJSON(t, w, map[string]any{"errorMessages": []string{"Issue does not exist"}})
+			return
+		}
+		if r.Method == http.MethodGet {
+			writeJSON(t, w, map[string]any{"transitions": transitions})
+			return
+		}
+		var req JiraTransitionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode transition: %v", err)
+		}
+		mu.Lock()
+		posted[key] = req.Transition.ID
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	keys := []string{"OPS-1", "OPS-404", "OPS-2"}
+	results := bulkApply(keys, 2, func(key string) (any, error) {
+		return transitionIssue(cfg, key, "Done", TransitionOptions{MatchTargets: true}, false)
+	})
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	for i, key := range keys {
+		if results[i].Key != key {
+			t.Fatalf("results out of order: %+v", results)
+		}
+	}
+	if !results[0].OK || !results[2].OK {
+		t.Fatalf("expected OPS-1 and OPS-2 to succeed, got %+v", results)
+	}
+	if results[1].OK || !strings.Contains(results[1].Error, "Issue does not exist") {
+		t.Fatalf("expected OPS-404 to fail with the API error, got %+v", results[1])
+	}
+	if posted["OPS-1"] != "31" || posted["OPS-2"] != "51" {
+		t.Fatalf("expected per-issue transition IDs, got %v", posted)
+	}
+}
@@ -0,0 +1,362 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// cacheTTLs are the per-kind lifetimes of cached metadata. Only slow-changing
+// lookups are cached; issues, transitions and versions are always live.
+var cacheTTLs = map[string]time.Duration{
+	"fields":     24 * time.Hour,
+	"issuetypes": 24 * time.Hour,
+	"linktypes":  24 * time.Hour,
+	"priorities": 24 * time.Hour,
+	"projects":   time.Hour,
+	"statuses":   24 * time.Hour,
+	"users":      24 * time.Hour,
+}
+
+// noCache is set by the global --no-cache flag.
+var noCache bool
+
+var unsafeCacheChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// ---------------------------------------------------------------------------
+// Cache types
+// ---------------------------------------------------------------------------
+
+type cacheEntry struct {
+	Key      string          `json:"key"`
+	StoredAt time.Time       `json:"stored_at"`
+	Value    json.RawMessage `json:"value"`
+}
+
+type CacheKindStats struct {
+	Profile string `json:"profile"`
+	Kind    string `json:"kind"`
+	TTL     string `json:"ttl"`
+	Entries int    `json:"entries"`
+	Expired int    `json:"expired"`
+	Bytes   int64  `json:"bytes"`
+}
+
+type CacheStats struct {
+	Dir      string           `json:"dir"`
+	Disabled bool             `json:"disabled,omitempty"`
+	Kinds    []CacheKindStats `json:"kinds"`
+}
+
+// ---------------------------------------------------------------------------
+// Help functions
+// ---------------------------------------------------------------------------
+
+func printCacheHelp() {
+	fmt.Println("jiractl cache commands:")
+	fmt.Println("  cache stats  [--json]")
+	fmt.Println("  cache clear  [--kind KIND] [--json]")
+	fmt.Println()
+	fmt.Println("kinds: " + strings.Join(sortedKeys(cacheTTLs), ", "))
+	fmt.Println("Pass --no-cache (or set JIRACTL_NO_CACHE=1) on any command to bypass the cache.")
+}
+
+// ---------------------------------------------------------------------------
+// Cache commands
+// ---------------------------------------------------------------------------
+
+func runCache(args []string) error {
+	if len(args) == 0 {
+		printCacheHelp()
+		return nil
+	}
+
+	switch args[0] {
+	case "stats":
+		return runCacheStats(args[1:])
+	case "clear":
+		return runCacheClear(args[1:])
+	case "help", "--help", "-h":
+		printCacheHelp()
+		return nil
+	default:
+		printCacheHelp()
+		return fmt.Errorf("unknown cache command %q", args[0])
+	}
+}
+
+func runCacheStats(args []string) error {
+	fs := flag.NewFlagSet("cache stats", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	stats, err := cacheStats(time.Now())
+	if err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		return printJSON(stats)
+	}
+
+	fmt.Printf("Cache: %s\n", stats.Dir)
+	if stats.Disabled {
+		fmt.Println("(disabled by --no-cache or JIRACTL_NO_CACHE)")
+	}
+	if len(stats.Kinds) == 0 {
+		fmt.Println("No cached entries.")
+		return nil
+	}
+	fmt.Println()
+	for _, k := range stats.Kinds {
+		fmt.Printf("- %-30s  %-10s  %3d entries  %3d expired  %8s  ttl %s\n",
+			k.Profile, k.Kind, k.Entries, k.Expired, formatBytes(k.Bytes), k.TTL)
+	}
+	return nil
+}
+
+func runCacheClear(args []string) error {
+	fs := flag.NewFlagSet("cache clear", flag.ContinueOnError)
+	kind := fs.String("kind", "", "only clear this kind of entry")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if _, ok := cacheTTLs[*kind]; *kind != "" && !ok {
+		return fmt.Errorf("unknown cache kind %q; one of: %s", *kind, strings.Join(sortedKeys(cacheTTLs), ", "))
+	}
+
+	removed, err := clearCache(*kind)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		return printJSON(map[string]any{"kind": *kind, "removed": removed})
+	}
+	fmt.Printf("Removed %d cached entries.\n", removed)
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Cache helpers
+// ---------------------------------------------------------------------------
+
+// cachedGet returns the cached value for kind/key when it is younger than the
+// kind's TTL, and otherwise calls fetch and stores the result. Empty results
+// are not stored, so a user or project created after a failed lookup is found
+// on the next call. Cache failures never fail the lookup; they only cost the
+// HTTP request.
+func cachedGet[T any](cfg Config, kind, key string, fetch func() (T, error)) (T, error) {
+	path, ok := cacheEntryPath(cfg, kind, key)
+	if ok {
+		var v T
+		if readCacheEntry(path, cacheTTLs[kind], time.Now(), &v) {
+			return v, nil
+		}
+	}
+
+	v, err := fetch()
+	if err != nil || !ok || isEmptyResult(v) {
+		return v, err
+	}
+	writeCacheEntry(path, key, v)
+	return v, nil
+}
+
+// isEmptyResult reports whether v is a nil or empty slice or map.
+func isEmptyResult(v any) bool {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	}
+	return false
+}
+
+func cacheEnabled() bool {
+	if noCache {
+		return false
+	}
+	switch strings.ToLower(os.Getenv("JIRACTL_NO_CACHE")) {
+	case "1", "true", "yes":
+		return false
+	}
+	return true
+}
+
+func cacheRoot() (string, error) {
+	d, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d, "cache"), nil
+}
+
+// cacheProfile names the per-account cache directory: the server host plus a
+// short hash of the email, so switching accounts never serves another
+// account's view of Jira.
+func cacheProfile(cfg Config) string {
+	host := cfg.Server
+	if u, err := url.Parse(cfg.Server); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	sum := sha256.Sum256([]byte(strings.ToLower(cfg.Email)))
+	return unsafeCacheChars.ReplaceAllString(host, "_") + "-" + hex.EncodeToString(sum[:4])
+}
+
+func cacheEntryPath(cfg Config, kind, key string) (string, bool) {
+	if !cacheEnabled() {
+		return "", false
+	}
+	if _, ok := cacheTTLs[kind]; !ok {
+		return "", false
+	}
+	root, err := cacheRoot()
+	if err != nil {
+		return "", false
+	}
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(root, cacheProfile(cfg), kind, hex.EncodeToString(sum[:8])+".json"), true
+}
+
+func readCacheEntry(path string, ttl time.Duration, now time.Time, out any) bool {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	var entry cacheEntry
+	if json.Unmarshal(b, &entry) != nil || now.Sub(entry.StoredAt) > ttl {
+		return false
+	}
+	return json.Unmarshal(entry.Value, out) == nil
+}
+
+func writeCacheEntry(path, key string, v any) {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	b, err := json.Marshal(cacheEntry{Key: key, StoredAt: time.Now().UTC(), Value: value})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	// Write-then-rename so a concurrent reader never sees a partial entry. A
+	// temp file of its own keeps concurrent writers of the same entry apart.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(b)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil || os.Rename(tmp.Name(), path) != nil {
+		_ = os.Remove(tmp.Name())
+	}
+}
+
+func cacheStats(now time.Time) (CacheStats, error) {
+	root, err := cacheRoot()
+	if err != nil {
+		return CacheStats{}, err
+	}
+	stats := CacheStats{Dir: root, Disabled: !cacheEnabled(), Kinds: []CacheKindStats{}}
+
+	profiles, err := os.ReadDir(root)
+	if errors.Is(err, os.ErrNotExist) {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, err
+	}
+	for _, p := range profiles {
+		if !p.IsDir() {
+			continue
+		}
+		for _, kind := range sortedKeys(cacheTTLs) {
+			files, err := os.ReadDir(filepath.Join(root, p.Name(), kind))
+			if err != nil {
+				continue
+			}
+			ks := CacheKindStats{Profile: p.Name(), Kind: kind, TTL: cacheTTLs[kind].String()}
+			for _, f := range files {
+				path := filepath.Join(root, p.Name(), kind, f.Name())
+				info, err := f.Info()
+				if err != nil || f.IsDir() || !strings.HasSuffix(f.Name(), ".json") {
+					continue
+				}
+				ks.Entries++
+				ks.Bytes += info.Size()
+				var v json.RawMessage
+				if !readCacheEntry(path, cacheTTLs[kind], now, &v) {
+					ks.Expired++
+				}
+			}
+			if ks.Entries > 0 {
+				stats.Kinds = append(stats.Kinds, ks)
+			}
+		}
+	}
+	return stats, nil
+}
+
+// clearCache removes every cached entry, or only entries of one kind, across
+// all profiles.
+func clearCache(kind string) (int, error) {
+	root, err := cacheRoot()
+	if err != nil {
+		return 0, err
+	}
+	removed := 0
+	err = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+		if kind != "" && filepath.Base(filepath.Dir(path)) != kind {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		removed++
+		return nil
+	})
+	return removed, err
+}
+
+// stripNoCacheFlag removes the global --no-cache flag from args, reporting
+// whether it was present.
+func stripNoCacheFlag(args []string) ([]string, bool) {
+	out := make([]string, 0, len(args))
+	found := false
+	for _, a := range args {
+		if a == "--no-cache" || a == "-no-cache" {
+			found = true
+			continue
+		}
+		out = append(out, a)
+	}
+	return out, found
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCachedGetServesFreshEntriesAndHonorsNoCache(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("JIRACTL_NO_CACHE", "")
+
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/field", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeJSON(t, w, []JiraField{{ID: "customfield_10016", Name: "Story Points"}})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+
+	for i := 0; i < 2; i++ {
+		fields, err := getFields(cfg)
+		if err != nil {
+			t.Fatalf("getFields returned error: %v", err)
+		}
+		if len(fields) != 1 || fields[0].Name != "Story Points" {
+			t.Fatalf("unexpected fields %+v", fields)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected the second lookup to be served from cache, got %d requests", calls)
+	}
+
+	noCache = true
+	_, err := getFields(cfg)
+	noCache = false
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected --no-cache to bypass the cache, got %d requests", calls)
+	}
+
+	stats, err := cacheStats(time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats.Kinds) != 1 || stats.Kinds[0].Kind != "fields" || stats.Kinds[0].Entries != 1 || stats.Kinds[0].Expired != 0 {
+		t.Fatalf("unexpected stats %+v", stats.Kinds)
+	}
+	if expired, _ := cacheStats(time.Now().Add(25 * time.Hour)); expired.Kinds[0].Expired != 1 {
+		t.Fatalf("expected the entry to expire after its TTL, got %+v", expired.Kinds)
+	}
+
+	removed, err := clearCache("")
+	if err != nil || removed != 1 {
+		t.Fatalf("clearCache removed %d entries, err %v", removed, err)
+	}
+	if _, err := getFields(cfg); err != nil || calls != 3 {
+		t.Fatalf("expected a fresh request after clear, got %d requests (err %v)", calls, err)
+	}
+}
+
+func TestCachedGetDoesNotStoreEmptyUserLookups(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("JIRACTL_NO_CACHE", "")
+
+	var users []JiraUser
+	calls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/user/search", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeJSON(t, w, users)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+
+	if found, err := searchUser(cfg, "new@example.com"); err != nil || len(found) != 0 {
+		t.Fatalf("expected no users, got %v, %v", found, err)
+	}
+	users = []JiraUser{{AccountID: "acc-2", EmailAddress: "new@example.com"}}
+	if found, err := searchUser(cfg, "new@example.com"); err != nil || len(found) != 1 {
+		t.Fatalf("expected the new user after a miss, got %v, %v", found, err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected the miss not to be cached, got %d requests", calls)
+	}
+}
+
+func TestWriteCacheEntryConcurrentWritersLeaveAValidEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users", "entry.json")
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			writeCacheEntry(path, "search:me", []JiraUser{{AccountID: strconv.Itoa(i)}})
+		}(i)
+	}
+	wg.Wait()
+
+	var users []JiraUser
+	if !readCacheEntry(path, time.Hour, time.Now(), &users) || len(users) != 1 {
+		t.Fatalf("expected a valid entry, got %+v", users)
+	}
+	if leftovers, _ := filepath.Glob(filepath.Join(filepath.Dir(path), "*.tmp")); len(leftovers) != 0 {
+		t.Fatalf("expected no temp files, got %v", leftovers)
+	}
+}
+
+func TestStripNoCacheFlag(t *testing.T) {
+	args, found := stripNoCacheFlag([]string{"jiractl", "issues", "--no-cache", "view", "PROJ-1"})
+	if !found || len(args) != 4 || args[2] != "view" {
+		t.Fatalf("unexpected result %v %v", args, found)
+	}
+}
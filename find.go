@@ -0,0 +1,345 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// BM25 parameters; the usual defaults.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// summaryBoost counts a summary term as this many body terms, since summaries
+// are short and written to describe the issue.
+const summaryBoost = 3
+
+// snippetRadius is the number of characters kept on each side of the first
+// match in a snippet.
+const snippetRadius = 80
+
+var searchStopwords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "but": true,
+	"by": true, "for": true, "from": true, "has": true, "have": true, "if": true, "in": true, "into": true,
+	"is": true, "it": true, "its": true, "of": true, "on": true, "or": true, "that": true, "the": true,
+	"this": true, "to": true, "was": true, "were": true, "when": true, "with": true,
+}
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type FindHit struct {
+	IssueView
+	Score     float64 `json:"score"`
+	MatchedIn string  `json:"matched_in"`
+	Snippet   string  `json:"snippet"`
+}
+
+type FindResult struct {
+	Query    string    `json:"query"`
+	Count    int       `json:"count"`
+	Total    int       `json:"total"`
+	Indexed  int       `json:"indexed"`
+	SyncedAt string    `json:"synced_at"`
+	Results  []FindHit `json:"results"`
+}
+
+// ---------------------------------------------------------------------------
+// Find command
+// ---------------------------------------------------------------------------
+
+func runFind(args []string) error {
+	fs := flag.NewFlagSet("find", flag.ContinueOnError)
+	var projects stringList
+	fs.Var(&projects, "project", "only issues in this project (repeatable)")
+	limit := fs.Int("limit", 10, "max results to return")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	query := strings.TrimSpace(strings.Join(positional, " "))
+	if query == "" {
+		return errors.New("a query is required (e.g. jiractl find \"memory leak in exporter\")")
+	}
+	if *limit <= 0 {
+		return errors.New("--limit must be greater than 0")
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+	m, err := openOfflineMirror(cfg)
+	if err != nil {
+		return err
+	}
+	issues, err := m.allIssues()
+	if err != nil {
+		return err
+	}
+	issues = filterMirrorProjects(issues, projects)
+
+	hits := buildSearchIndex(issues).search(query)
+	out := FindResult{
+		Query:    query,
+		Total:    len(hits),
+		Indexed:  len(issues),
+		SyncedAt: m.syncedAt().Format(time.RFC3339),
+		Results:  []FindHit{},
+	}
+	terms := searchTerms(query)
+	for i := 0; i < len(hits) && i < *limit; i++ {
+		mi := &issues[hits[i].doc]
+		field, snippet := matchSnippet(mi, terms)
+		out.Results = append(out.Results, FindHit{
+			IssueView: issueToView(mi.Issue, cfg.Server),
+			Score:     math.Round(hits[i].score*1000) / 1000,
+			MatchedIn: field,
+			Snippet:   snippet,
+		})
+	}
+	out.Count = len(out.Results)
+
+	if *jsonOut {
+		return printJSON(out)
+	}
+
+	printOfflineNotice(m.syncedAt())
+	if out.Count == 0 {
+		fmt.Printf("No matches in %d mirrored issues.\n", out.Indexed)
+		return nil
+	}
+	if out.Total > out.Count {
+		fmt.Printf("Matches (%d of %d):\n", out.Count, out.Total)
+	} else {
+		fmt.Printf("Matches (%d):\n", out.Count)
+	}
+	for _, h := range out.Results {
+		fmt.Printf("\n- %-12s  %6.2f  [%s]  %s\n", h.Key, h.Score, h.Status, h.Summary)
+		if h.MatchedIn != "summary" {
+			fmt.Printf("  %s: %s\n", h.MatchedIn, h.Snippet)
+		}
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Search index
+// ---------------------------------------------------------------------------
+
+type searchPosting struct {
+	doc int
+	tf  float64
+}
+
+type searchHit struct {
+	doc   int
+	score float64
+}
+
+// searchIndex is an in-memory inverted index over issue summaries,
+// descriptions and comments, ranked with BM25.
+type searchIndex struct {
+	postings map[string][]searchPosting
+	lengths  []float64
+	avgLen   float64
+}
+
+func buildSearchIndex(issues []MirrorIssue) *searchIndex {
+	ix := &searchIndex{postings: map[string][]searchPosting{}, lengths: make([]float64, len(issues))}
+	total := 0.0
+	for i := range issues {
+		tf := map[string]float64{}
+		for j, text := range mirrorIssueTexts(&issues[i]) {
+			weight := 1.0
+			if j == 0 {
+				weight = summaryBoost
+			}
+			for _, term := range searchTerms(text.body) {
+				tf[term] += weight
+				ix.lengths[i] += weight
+			}
+		}
+		for term, n := range tf {
+			ix.postings[term] = append(ix.postings[term], searchPosting{doc: i, tf: n})
+		}
+		total += ix.lengths[i]
+	}
+	if len(issues) > 0 {
+		ix.avgLen = total / float64(len(issues))
+	}
+	return ix
+}
+
+// search ranks every document containing at least one query term, best first.
+func (ix *searchIndex) search(query string) []searchHit {
+	n := float64(len(ix.lengths))
+	scores := map[int]float64{}
+	seen := map[string]bool{}
+	for _, term := range searchTerms(query) {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		postings := ix.postings[term]
+		if len(postings) == 0 {
+			continue
+		}
+		df := float64(len(postings))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for _, p := range postings {
+			norm := bm25K1 * (1 - bm25B + bm25B*ix.lengths[p.doc]/ix.avgLen)
+			scores[p.doc] += idf * p.tf * (bm25K1 + 1) / (p.tf + norm)
+		}
+	}
+
+	hits := make([]searchHit, 0, len(scores))
+	for doc, score := range scores {
+		hits = append(hits, searchHit{doc: doc, score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return hits[i].doc < hits[j].doc
+	})
+	return hits
+}
+
+// ---------------------------------------------------------------------------
+// Find helpers
+// ---------------------------------------------------------------------------
+
+type issueText struct {
+	field string
+	body  string
+}
+
+// mirrorIssueTexts returns the searchable texts of an issue, summary first.
+func mirrorIssueTexts(mi *MirrorIssue) []issueText {
+	texts := []issueText{
+		{field: "summary", body: mi.Issue.Fields.Summary},
+		{field: "description", body: adfToText(mi.Issue.Fields.Description)},
+	}
+	for _, c := range mi.Comments {
+		texts = append(texts, issueText{field: "comment", body: adfToText(c.Body)})
+	}
+	return texts
+}
+
+// searchTerms splits text into lower-cased words, dropping stopwords and
+// single characters and folding simple plurals ("leaks" matches "leak").
+func searchTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := make([]string, 0, len(words))
+	for _, w := range words {
+		if len([]rune(w)) < 2 || searchStopwords[w] {
+			continue
+		}
+		terms = append(terms, stemTerm(w))
+	}
+	return terms
+}
+
+func stemTerm(w string) string {
+	if len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") &&
+		!strings.HasSuffix(w, "us") && !strings.HasSuffix(w, "is") {
+		return strings.TrimSuffix(w, "s")
+	}
+	return w
+}
+
+// matchSnippet picks the text matching the most distinct query terms and cuts
+// a window around its first match.
+func matchSnippet(mi *MirrorIssue, terms []string) (string, string) {
+	best, bestCount := issueText{}, 0
+	for _, text := range mirrorIssueTexts(mi) {
+		have := map[string]bool{}
+		for _, t := range searchTerms(text.body) {
+			have[t] = true
+		}
+		count := 0
+		for _, t := range uniqueStrings(terms) {
+			if have[t] {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = text, count
+		}
+	}
+	if bestCount == 0 {
+		return "", ""
+	}
+
+	body := strings.Join(strings.Fields(best.body), " ")
+	runes := []rune(body)
+	lower := []rune(strings.ToLower(body))
+	if len(lower) != len(runes) {
+		lower = runes
+	}
+	first := -1
+	for _, t := range terms {
+		if i := strings.Index(string(lower), t); i >= 0 {
+			pos := len([]rune(string(lower)[:i]))
+			if first < 0 || pos < first {
+				first = pos
+			}
+		}
+	}
+	if first < 0 {
+		first = 0
+	}
+
+	start, end := maxInt(0, first-snippetRadius), minInt(len(runes), first+snippetRadius)
+	snippet := string(runes[start:end])
+	if start > 0 {
+		snippet = "..." + snippet
+	}
+	if end < len(runes) {
+		snippet += "..."
+	}
+	return best.field, snippet
+}
+
+// filterMirrorProjects keeps issues of the given projects; no projects keeps
+// everything.
+func filterMirrorProjects(issues []MirrorIssue, projects []string) []MirrorIssue {
+	if len(projects) == 0 {
+		return issues
+	}
+	var out []MirrorIssue
+	for _, mi := range issues {
+		project, _ := splitIssueKey(mi.Issue.Key)
+		for _, p := range projects {
+			if strings.EqualFold(project, p) {
+				out = append(out, mi)
+				break
+			}
+		}
+	}
+	return out
+}
+
+func uniqueStrings(values []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
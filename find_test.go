@@ -0,0 +1,43 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSearchIndexRanksWithBM25(t *testing.T) {
+	issues := []MirrorIssue{
+		{Issue: JiraIssue{Key: "PROJ-1", Fields: JiraIssueFields{Summary: "Exporter leaks memory on large batches"}}},
+		{Issue: JiraIssue{Key: "PROJ-2", Fields: JiraIssueFields{
+			Summary:     "Slow dashboard",
+			Description: "Profiling shows the memory usage of the dashboard is fine.",
+		}}},
+		{Issue: JiraIssue{Key: "PROJ-3", Fields: JiraIssueFields{Summary: "Rename settings page"}},
+			Comments: []JiraComment{{Body: "Unrelated, but the exporter crashed again last night."}}},
+	}
+
+	hits := buildSearchIndex(issues).search("memory leak in exporter")
+	var keys []string
+	for _, h := range hits {
+		keys = append(keys, issues[h.doc].Issue.Key)
+	}
+	if len(keys) != 3 || keys[0] != "PROJ-1" || hits[0].score <= hits[1].score {
+		t.Fatalf("expected PROJ-1 to rank first of 3 hits, got %q", strings.Join(keys, ","))
+	}
+}
+
+func TestMatchSnippetCutsAroundFirstMatch(t *testing.T) {
+	long := strings.Repeat("filler words here ", 20) + "the exporter leaks memory when the queue backs up " + strings.Repeat("more filler ", 20)
+	mi := &MirrorIssue{
+		Issue:    JiraIssue{Key: "PROJ-1", Fields: JiraIssueFields{Summary: "Background job issue"}},
+		Comments: []JiraComment{{Body: long}},
+	}
+
+	field, snippet := matchSnippet(mi, searchTerms("memory leaks"))
+	if field != "comment" {
+		t.Fatalf("expected a comment match, got %q", field)
+	}
+	if !containsAll(snippet, []string{"...", "exporter leaks memory"}) || len([]rune(snippet)) > 2*snippetRadius+6 {
+		t.Fatalf("unexpected snippet %q", snippet)
+	}
+}
@@ -0,0 +1,499 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// defaultBranchPattern finds an issue key anywhere in a branch name, e.g.
+// "feature/proj-123-fix-login".
+const defaultBranchPattern = `(?i)\b([a-z][a-z0-9_]*-[0-9]+)`
+
+const defaultSlugLength = 50
+
+// commitKeyPattern matches issue keys in commit messages. Jira project keys
+// are upper-case, so "utf-8" and similar lower-case words never match.
+var commitKeyPattern = regexp.MustCompile(`\b([A-Z][A-Z0-9_]+)-([1-9][0-9]*)\b`)
+
+// missingKeyPattern finds the quoted keys in Jira's "An issue with key
+// 'PROJ-9' does not exist" JQL errors.
+var missingKeyPattern = regexp.MustCompile(`'([A-Z][A-Z0-9_]+-[0-9]+)'`)
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type BranchResult struct {
+	Key        string            `json:"key"`
+	Branch     string            `json:"branch"`
+	Created    bool              `json:"created"`
+	Transition *TransitionResult `json:"transition,omitempty"`
+	Assign     *AssignResult     `json:"assign,omitempty"`
+	DryRun     bool              `json:"dry_run,omitempty"`
+	URL        string            `json:"url"`
+}
+
+type GitCommit struct {
+	Hash    string `json:"hash"`
+	Subject string `json:"subject"`
+	Body    string `json:"-"`
+}
+
+type GitIssueView struct {
+	IssueView
+	// ReferencedAs is the key the commits use when the issue has since
+	// been moved or its project renamed.
+	ReferencedAs string      `json:"referenced_as,omitempty"`
+	Commits      []GitCommit `json:"commits"`
+}
+
+type GitMissingKey struct {
+	Key     string      `json:"key"`
+	Commits []GitCommit `json:"commits"`
+}
+
+type GitIssuesResult struct {
+	Range   string          `json:"range"`
+	Commits int             `json:"commits"`
+	Issues  []GitIssueView  `json:"issues"`
+	Missing []GitMissingKey `json:"missing"`
+	// Unreferenced lists commits that mention no issue key.
+	Unreferenced []GitCommit `json:"unreferenced"`
+}
+
+// ---------------------------------------------------------------------------
+// Help functions
+// ---------------------------------------------------------------------------
+
+func printGitHelp() {
+	fmt.Println("jiractl git commands:")
+	fmt.Println("  git branch  ISSUE-KEY [--transition STATUS] [--assign-me] [--prefix feature/] [--base REF] [--dry-run] [--json]")
+	fmt.Println("  git issues  RANGE [--project KEY]... [--json]")
+}
+
+// ---------------------------------------------------------------------------
+// Git commands
+// ---------------------------------------------------------------------------
+
+func runGit(args []string) error {
+	if len(args) == 0 {
+		printGitHelp()
+		return nil
+	}
+
+	switch args[0] {
+	case "branch":
+		return runGitBranch(args[1:])
+	case "issues":
+		return runGitIssues(args[1:])
+	case "help", "--help", "-h":
+		printGitHelp()
+		return nil
+	default:
+		printGitHelp()
+		return fmt.Errorf("unknown git command %q", args[0])
+	}
+}
+
+func runGitBranch(args []string) error {
+	fs := flag.NewFlagSet("git branch", flag.ContinueOnError)
+	transition := fs.String("transition", "", "also transition the issue, e.g. \"In Progress\"")
+	assignMe := fs.Bool("assign-me", false, "also assign the issue to you")
+	prefix := fs.String("prefix", "", "branch name prefix, e.g. feature/")
+	base := fs.String("base", "", "start the branch from this ref (default: HEAD)")
+	maxLength := fs.Int("max-length", defaultSlugLength, "max length of the summary part")
+	dryRun := fs.Bool("dry-run", false, "print the branch name without creating it")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("issue key is required (e.g. jiractl git branch PROJ-123)")
+	}
+	issueKey := strings.ToUpper(positional[0])
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	issue, err := getIssue(cfg, issueKey)
+	if err != nil {
+		return err
+	}
+
+	branch := *prefix + issueKey
+	if slug := slugify(issue.Fields.Summary, *maxLength); slug != "" {
+		branch += "-" + slug
+	}
+
+	result := BranchResult{Key: issueKey, Branch: branch, DryRun: *dryRun, URL: cfg.Server + "/browse/" + issueKey}
+	if !*dryRun {
+		gitArgs := []string{"checkout", "-b", branch}
+		if *base != "" {
+			gitArgs = append(gitArgs, *base)
+		}
+		if _, err := gitOutput(gitArgs...); err != nil {
+			return err
+		}
+		result.Created = true
+	}
+
+	// The branch exists at this point; Jira updates are best effort and
+	// reported as warnings.
+	if *transition != "" {
+		tr, err := transitionIssue(cfg, issueKey, *transition, TransitionOptions{}, *dryRun)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "warning: transition failed:", err)
+		} else {
+			result.Transition = &tr
+		}
+	}
+	if *assignMe {
+		me, err := getMyself(cfg)
+		if err == nil {
+			var ar AssignResult
+			ar, err = assignIssueTo(cfg, issueKey, &me, *dryRun)
+			result.Assign = &ar
+		}
+		if err != nil {
+			result.Assign = nil
+			fmt.Fprintln(os.Stderr, "warning: assign failed:", err)
+		}
+	}
+
+	if *jsonOut {
+		return printJSON(result)
+	}
+
+	if *dryRun {
+		fmt.Printf("Would create branch %s\n", result.Branch)
+	} else {
+		fmt.Printf("Switched to new branch %s\n", result.Branch)
+	}
+	if result.Transition != nil {
+		fmt.Printf("%s transitioned to %s\n", issueKey, result.Transition.Status)
+	}
+	if result.Assign != nil {
+		fmt.Printf("%s assigned to %s\n", issueKey, firstNonEmpty(result.Assign.AssigneeName, result.Assign.Assignee))
+	}
+	return nil
+}
+
+func runIssuesCurrent(args []string) error {
+	fs := flag.NewFlagSet("issues current", flag.ContinueOnError)
+	pattern := fs.String("pattern", "", "regex for the issue key in the branch name (default $JIRACTL_BRANCH_PATTERN or PROJ-123 anywhere)")
+	commentLimit := fs.Int("comment-limit", 20, "max comments to return")
+	offline := fs.Bool("offline", false, "answer from the local mirror (see jiractl sync)")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *commentLimit <= 0 {
+		return errors.New("--comment-limit must be greater than 0")
+	}
+
+	branch, err := gitOutput("rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return err
+	}
+	re, err := branchPattern(*pattern)
+	if err != nil {
+		return err
+	}
+	issueKey := issueKeyFromBranch(re, branch)
+	if issueKey == "" {
+		return fmt.Errorf("no issue key found in branch %q (pattern %s)", branch, re)
+	}
+
+	return showIssue(issueKey, *commentLimit, offlineRequested(*offline), *jsonOut)
+}
+
+func runGitIssues(args []string) error {
+	fs := flag.NewFlagSet("git issues", flag.ContinueOnError)
+	var projects stringList
+	fs.Var(&projects, "project", "only match keys of this project (repeatable; default: all projects you can see)")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("revision range is required (e.g. jiractl git issues v1.2.0..HEAD)")
+	}
+	revRange := positional[0]
+
+	commits, err := gitCommits(revRange)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+	if len(projects) == 0 {
+		if projects, err = getProjectKeys(cfg); err != nil {
+			return err
+		}
+	}
+
+	out, err := scanGitIssues(cfg, revRange, commits, projects)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		return printJSON(out)
+	}
+
+	fmt.Printf("%d commits in %s, %d issues\n", out.Commits, out.Range, len(out.Issues))
+	for _, issue := range out.Issues {
+		fmt.Printf("\n- %-12s  [%s]  %s\n", issue.Key, issue.Status, issue.Summary)
+		if issue.ReferencedAs != "" {
+			fmt.Printf("    (referenced as %s)\n", issue.ReferencedAs)
+		}
+		for _, c := range issue.Commits {
+			fmt.Printf("    %s  %s\n", shortHash(c.Hash), c.Subject)
+		}
+	}
+	if len(out.Missing) > 0 {
+		fmt.Println("\nReferenced keys not found in Jira:")
+		for _, m := range out.Missing {
+			fmt.Printf("- %-12s  %d commit(s), e.g. %s\n", m.Key, len(m.Commits), shortHash(m.Commits[0].Hash))
+		}
+	}
+	if len(out.Unreferenced) > 0 {
+		fmt.Printf("\n%d commit(s) reference no issue.\n", len(out.Unreferenced))
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Git helpers
+// ---------------------------------------------------------------------------
+
+// gitOutput runs git and returns its trimmed stdout, or an error carrying
+// git's stderr.
+func gitOutput(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("git %s: %s", args[0], msg)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// branchPattern compiles the issue key pattern: flag > env > default.
+func branchPattern(flagValue string) (*regexp.Regexp, error) {
+	p := firstNonEmpty(flagValue, os.Getenv("JIRACTL_BRANCH_PATTERN"), defaultBranchPattern)
+	re, err := regexp.Compile(p)
+	if err != nil {
+		return nil, fmt.Errorf("invalid branch pattern %q: %w", p, err)
+	}
+	return re, nil
+}
+
+// issueKeyFromBranch returns the first capture group (or the whole match)
+// of re in branch, upper-cased.
+func issueKeyFromBranch(re *regexp.Regexp, branch string) string {
+	m := re.FindStringSubmatch(branch)
+	if m == nil {
+		return ""
+	}
+	key := m[0]
+	if len(m) > 1 && m[1] != "" {
+		key = m[1]
+	}
+	return strings.ToUpper(key)
+}
+
+// slugify lower-cases s, joins runs of letters and digits with dashes and
+// cuts the result at a word boundary within maxLen.
+func slugify(s string, maxLen int) string {
+	var words []string
+	var cur strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			cur.WriteRune(r)
+			continue
+		}
+		if cur.Len() > 0 {
+			words = append(words, cur.String())
+			cur.Reset()
+		}
+	}
+	if cur.Len() > 0 {
+		words = append(words, cur.String())
+	}
+
+	slug := ""
+	for _, w := range words {
+		next := w
+		if slug != "" {
+			next = slug + "-" + w
+		}
+		if maxLen > 0 && len(next) > maxLen {
+			if slug == "" {
+				slug = w[:maxLen]
+			}
+			break
+		}
+		slug = next
+	}
+	return slug
+}
+
+// gitCommits lists the commits in a revision range, oldest first.
+func gitCommits(revRange string) ([]GitCommit, error) {
+	// Fields are separated by US (0x1f) and commits by RS (0x1e), which
+	// don't occur in commit messages.
+	out, err := gitOutput("log", "--reverse", "--format=%H%x1f%s%x1f%b%x1e", revRange, "--")
+	if err != nil {
+		return nil, err
+	}
+	var commits []GitCommit
+	for _, record := range strings.Split(out, "\x1e") {
+		parts := strings.SplitN(strings.TrimSpace(record), "\x1f", 3)
+		if len(parts) < 2 {
+			continue
+		}
+		c := GitCommit{Hash: parts[0], Subject: parts[1]}
+		if len(parts) == 3 {
+			c.Body = strings.TrimSpace(parts[2])
+		}
+		commits = append(commits, c)
+	}
+	return commits, nil
+}
+
+// extractIssueKeys returns the distinct issue keys in text, in order of
+// appearance, keeping only keys of the given projects.
+func extractIssueKeys(text string, projects map[string]bool) []string {
+	var keys []string
+	seen := map[string]bool{}
+	for _, m := range commitKeyPattern.FindAllStringSubmatch(text, -1) {
+		if !projects[m[1]] || seen[m[0]] {
+			continue
+		}
+		seen[m[0]] = true
+		keys = append(keys, m[0])
+	}
+	return keys
+}
+
+// scanGitIssues maps commits to the issue keys they mention and fetches
+// those issues with one search.
+func scanGitIssues(cfg Config, revRange string, commits []GitCommit, projects []string) (GitIssuesResult, error) {
+	out := GitIssuesResult{Range: revRange, Commits: len(commits), Issues: []GitIssueView{}, Missing: []GitMissingKey{}, Unreferenced: []GitCommit{}}
+
+	known := map[string]bool{}
+	for _, p := range projects {
+		known[strings.ToUpper(p)] = true
+	}
+
+	var keys []string
+	byKey := map[string][]GitCommit{}
+	for _, c := range commits {
+		found := extractIssueKeys(c.Subject+"\n"+c.Body, known)
+		if len(found) == 0 {
+			out.Unreferenced = append(out.Unreferenced, c)
+		}
+		for _, key := range found {
+			if byKey[key] == nil {
+				keys = append(keys, key)
+			}
+			byKey[key] = append(byKey[key], c)
+		}
+	}
+	if len(keys) == 0 {
+		return out, nil
+	}
+
+	issues, missing, err := searchIssueKeys(cfg, keys)
+	if err != nil {
+		return out, err
+	}
+	fetched := map[string]JiraIssue{}
+	for _, issue := range issues {
+		fetched[issue.Key] = issue
+	}
+	for _, key := range keys {
+		issue, ok := fetched[key]
+		if !ok && !containsString(missing, key) {
+			// Jira found the key but returned the issue under its new key
+			// after a move or project rename; fetching the old key follows
+			// the redirect and tells us which issue it was.
+			issue, err = getIssue(cfg, key)
+			if err != nil {
+				return out, err
+			}
+			ok = true
+		}
+		if !ok {
+			out.Missing = append(out.Missing, GitMissingKey{Key: key, Commits: byKey[key]})
+			continue
+		}
+		view := GitIssueView{IssueView: issueToView(issue, cfg.Server), Commits: byKey[key]}
+		if issue.Key != key {
+			view.ReferencedAs = key
+		}
+		out.Issues = append(out.Issues, view)
+	}
+	return out, nil
+}
+
+// searchIssueKeys fetches issues by key. Jira rejects the whole query when a
+// key doesn't exist, so keys named in such errors are dropped, returned as
+// missing, and the search is retried.
+func searchIssueKeys(cfg Config, keys []string) ([]JiraIssue, []string, error) {
+	remaining := keys
+	var missing []string
+	for len(remaining) > 0 {
+		found, err := searchIssues(cfg, keysJQL(remaining), len(remaining))
+		if err == nil {
+			return found.Issues, missing, nil
+		}
+		var kept []string
+		for _, key := range remaining {
+			if containsString(missingKeys(err.Error()), key) {
+				missing = append(missing, key)
+			} else {
+				kept = append(kept, key)
+			}
+		}
+		if len(kept) == len(remaining) {
+			return nil, nil, err
+		}
+		remaining = kept
+	}
+	return nil, missing, nil
+}
+
+func missingKeys(msg string) []string {
+	var keys []string
+	for _, m := range missingKeyPattern.FindAllStringSubmatch(msg, -1) {
+		keys = append(keys, m[1])
+	}
+	return keys
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 10 {
+		return hash[:10]
+	}
+	return hash
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	cases := []struct {
+		in   string
+		max  int
+		want string
+	}{
+		{"Fix login redirect (SSO)", 50, "fix-login-redirect-sso"},
+		{"  --Crash: on  'save'!! ", 50, "crash-on-save"},
+		{"Improve performance of dashboard loading", 20, "improve-performance"},
+		{"Supercalifragilistic", 5, "super"},
+		{"!!!", 50, ""},
+	}
+	for _, tc := range cases {
+		if got := slugify(tc.in, tc.max); got != tc.want {
+			t.Fatalf("slugify(%q, %d) = %q, want %q", tc.in, tc.max, got, tc.want)
+		}
+	}
+}
+
+func TestIssueKeyFromBranch(t *testing.T) {
+	t.Setenv("JIRACTL_BRANCH_PATTERN", "")
+	re, err := branchPattern("")
+	if err != nil {
+		t.Fatalf("branchPattern: %v", err)
+	}
+	cases := map[string]string{
+		"feature/proj-123-fix-login": "PROJ-123",
+		"PROJ-9":                     "PROJ-9",
+		"bugfix/ABC_DEF-42":          "ABC_DEF-42",
+		"main":                       "",
+	}
+	for branch, want := range cases {
+		if got := issueKeyFromBranch(re, branch); got != want {
+			t.Fatalf("issueKeyFromBranch(%q) = %q, want %q", branch, got, want)
+		}
+	}
+
+	custom, err := branchPattern(`^[a-z]+/([A-Z]+-\d+)`)
+	if err != nil {
+		t.Fatalf("branchPattern: %v", err)
+	}
+	if got := issueKeyFromBranch(custom, "jdoe/OPS-7-PROJ-8"); got != "OPS-7" {
+		t.Fatalf("custom pattern got %q, want OPS-7", got)
+	}
+}
+
+func TestScanGitIssuesMapsCommitsAndReportsMissingKeys(t *testing.T) {
+	var queries []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		jql := r.URL.Query().Get("jql")
+		queries = append(queries, jql)
+		if strings.Contains(jql, "PROJ-404") {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(t, w, JiraAPIError{ErrorMessages: []string{"An issue with key 'PROJ-404' does not exist for field 'key'."}})
+			return
+		}
+		writeJSON(t, w, JiraSearchResponse{Issues: []JiraIssue{
+			{Key: "PROJ-2", Fields: JiraIssueFields{Summary: "Second"}},
+			{Key: "PROJ-1", Fields: JiraIssueFields{Summary: "First"}},
+		}})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	commits := []GitCommit{
+		{Hash: "aaa", Subject: "PROJ-1: fix login", Body: "Also touches PROJ-2 and UTF-8 handling"},
+		{Hash: "bbb", Subject: "Bump deps"},
+		{Hash: "ccc", Subject: "PROJ-1 follow-up, see OTHER-5"},
+		{Hash: "ddd", Subject: "Typo in PROJ-404"},
+	}
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	out, err := scanGitIssues(cfg, "v1..HEAD", commits, []string{"PROJ"})
+	if err != nil {
+		t.Fatalf("scanGitIssues returned error: %v", err)
+	}
+
+	if len(queries) != 2 || queries[1] != "key in (PROJ-1, PROJ-2)" {
+		t.Fatalf("expected a retry without the missing key, got %q", queries)
+	}
+	if len(out.Issues) != 2 || out.Issues[0].Key != "PROJ-1" || out.Issues[1].Key != "PROJ-2" {
+		t.Fatalf("expected PROJ-1 then PROJ-2, got %+v", out.Issues)
+	}
+	if got := len(out.Issues[0].Commits); got != 2 {
+		t.Fatalf("expected 2 commits for PROJ-1, got %d", got)
+	}
+	if len(out.Missing) != 1 || out.Missing[0].Key != "PROJ-404" || out.Missing[0].Commits[0].Hash != "ddd" {
+		t.Fatalf("expected PROJ-404 to be missing, got %+v", out.Missing)
+	}
+	if len(out.Unreferenced) != 1 || out.Unreferenced[0].Hash != "bbb" {
+		t.Fatalf("expected one unreferenced commit, got %+v", out.Unreferenced)
+	}
+}
+
+func TestScanGitIssuesFindsMovedIssuesUnderTheirOldKey(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		// Jira resolves OLD-7 but reports it under its current key.
+		writeJSON(t, w, JiraSearchResponse{Issues: []JiraIssue{
+			{Key: "PROJ-1", Fields: JiraIssueFields{Summary: "First"}},
+			{Key: "NEW-3", Fields: JiraIssueFields{Summary: "Moved"}},
+		}})
+	})
+	mux.HandleFunc("/rest/api/3/issue/OLD-7", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, JiraIssue{Key: "NEW-3", Fields: JiraIssueFields{Summary: "Moved"}})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	commits := []GitCommit{
+		{Hash: "aaa", Subject: "PROJ-1: fix login"},
+		{Hash: "bbb", Subject: "OLD-7: tidy up"},
+	}
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	out, err := scanGitIssues(cfg, "v1..HEAD", commits, []string{"PROJ", "OLD"})
+	if err != nil {
+		t.Fatalf("scanGitIssues returned error: %v", err)
+	}
+	if len(out.Missing) != 0 {
+		t.Fatalf("expected no missing keys, got %+v", out.Missing)
+	}
+	if len(out.Issues) != 2 || out.Issues[1].Key != "NEW-3" || out.Issues[1].ReferencedAs != "OLD-7" {
+		t.Fatalf("expected OLD-7 to resolve to NEW-3, got %+v", out.Issues)
+	}
+	if out.Issues[1].Commits[0].Hash != "bbb" {
+		t.Fatalf("expected the OLD-7 commit on NEW-3, got %+v", out.Issues[1].Commits)
+	}
+}
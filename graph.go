@@ -0,0 +1,523 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// graphFields is the field list needed to walk issue relationships.
+const graphFields = "summary,status,issuetype,issuelinks,parent,subtasks"
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type GraphView struct {
+	Server       string      `json:"server"`
+	Nodes        []GraphNode `json:"nodes"`
+	Edges        []GraphEdge `json:"edges"`
+	Cycles       [][]string  `json:"cycles"`
+	Order        []string    `json:"order"`
+	CriticalPath []string    `json:"critical_path"`
+}
+
+type GraphNode struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	Status  string `json:"status"`
+	Type    string `json:"type"`
+	Depth   int    `json:"depth"`
+	URL     string `json:"url"`
+}
+
+// GraphEdge is a directed relationship. For "blocks" edges From blocks To;
+// for "parent" and "epic" edges From is the parent of To.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Type string `json:"type"`
+}
+
+// ---------------------------------------------------------------------------
+// Graph command
+// ---------------------------------------------------------------------------
+
+func runGraph(args []string) error {
+	fs := flag.NewFlagSet("graph", flag.ContinueOnError)
+	jql := fs.String("jql", "", "JQL query selecting the seed issues")
+	depth := fs.Int("depth", 1, "relationship hops to follow from the seed issues")
+	limit := fs.Int("limit", 50, "max seed issues")
+	maxNodes := fs.Int("max-nodes", 500, "stop walking once this many issues are loaded")
+	format := fs.String("format", "mermaid", "output format: dot, mermaid or json")
+	jsonOut := fs.Bool("json", false, "shorthand for --format json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *jql == "" {
+		return errors.New("--jql is required (e.g. --jql \"project = PROJ AND sprint in openSprints()\")")
+	}
+	if *depth < 0 {
+		return errors.New("--depth must not be negative")
+	}
+	if *limit <= 0 || *maxNodes <= 0 {
+		return errors.New("--limit and --max-nodes must be greater than 0")
+	}
+	if *jsonOut {
+		*format = "json"
+	}
+	switch *format {
+	case "dot", "mermaid", "json":
+	default:
+		return fmt.Errorf("unknown --format %q (use dot, mermaid or json)", *format)
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	graph, err := walkIssueGraph(cfg, *jql, *limit, *depth, *maxNodes)
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case "json":
+		return printJSON(graph)
+	case "dot":
+		fmt.Print(graphToDot(graph))
+	default:
+		fmt.Print(graphToMermaid(graph))
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Graph walking
+// ---------------------------------------------------------------------------
+
+// walkIssueGraph loads the seed issues and follows blocks links, parents,
+// subtasks and children breadth-first for up to depth hops.
+func walkIssueGraph(cfg Config, jql string, limit, depth, maxNodes int) (GraphView, error) {
+	seeds, err := searchIssuesWithFields(cfg, jql, limit, graphFields)
+	if err != nil {
+		return GraphView{}, err
+	}
+
+	issues := map[string]JiraIssue{}
+	depths := map[string]int{}
+	var frontier []string
+	for _, issue := range seeds.Issues {
+		issues[issue.Key] = issue
+		depths[issue.Key] = 0
+		frontier = append(frontier, issue.Key)
+	}
+
+	for d := 1; d <= depth && len(frontier) > 0 && len(issues) < maxNodes; d++ {
+		var next []string
+		queue := func(key string) {
+			if _, ok := depths[key]; ok || key == "" {
+				return
+			}
+			depths[key] = d
+			next = append(next, key)
+		}
+
+		for _, key := range frontier {
+			for _, e := range issueGraphEdges(issues[key]) {
+				queue(e.From)
+				queue(e.To)
+			}
+		}
+
+		// Epic children are only discoverable from the child side.
+		for _, chunk := range chunkStrings(frontier, 100) {
+			children, err := searchIssuesWithFields(cfg, "parent in ("+strings.Join(chunk, ", ")+")", maxNodes, graphFields)
+			if err != nil {
+				return GraphView{}, err
+			}
+			for _, child := range children.Issues {
+				if _, ok := issues[child.Key]; !ok {
+					issues[child.Key] = child
+					depths[child.Key] = d
+					next = append(next, child.Key)
+				}
+			}
+		}
+
+		var missing []string
+		for _, key := range next {
+			if _, ok := issues[key]; !ok {
+				missing = append(missing, key)
+			}
+		}
+		if room := maxNodes - len(issues); len(missing) > room {
+			missing = missing[:maxInt(room, 0)]
+		}
+		for _, chunk := range chunkStrings(missing, 100) {
+			found, err := searchIssuesWithFields(cfg, keysJQL(chunk), len(chunk), graphFields)
+			if err != nil {
+				return GraphView{}, err
+			}
+			for _, issue := range found.Issues {
+				issues[issue.Key] = issue
+			}
+		}
+
+		frontier = frontier[:0]
+		for _, key := range next {
+			if _, ok := issues[key]; ok {
+				frontier = append(frontier, key)
+			}
+		}
+	}
+
+	return buildIssueGraph(issues, depths, cfg.Server), nil
+}
+
+// issueGraphEdges returns the relationships visible on a single issue.
+func issueGraphEdges(issue JiraIssue) []GraphEdge {
+	var edges []GraphEdge
+	for _, l := range issue.Fields.IssueLinks {
+		if !isBlocksLinkType(l.Type) {
+			continue
+		}
+		if l.OutwardIssue != nil {
+			edges = append(edges, GraphEdge{From: issue.Key, To: l.OutwardIssue.Key, Type: "blocks"})
+		}
+		if l.InwardIssue != nil {
+			edges = append(edges, GraphEdge{From: l.InwardIssue.Key, To: issue.Key, Type: "blocks"})
+		}
+	}
+	if p := issue.Fields.Parent; p != nil && p.Key != "" {
+		edgeType := "parent"
+		if strings.EqualFold(nameOrEmpty(p.Fields.IssueType), "Epic") {
+			edgeType = "epic"
+		}
+		edges = append(edges, GraphEdge{From: p.Key, To: issue.Key, Type: edgeType})
+	}
+	for _, st := range issue.Fields.Subtasks {
+		edges = append(edges, GraphEdge{From: issue.Key, To: st.Key, Type: "parent"})
+	}
+	return edges
+}
+
+func isBlocksLinkType(t JiraIssueLinkType) bool {
+	return strings.EqualFold(t.Name, "Blocks") || strings.Contains(strings.ToLower(t.Outward), "block")
+}
+
+// buildIssueGraph assembles nodes and de-duplicated edges between loaded
+// issues, then runs the ordering analysis.
+func buildIssueGraph(issues map[string]JiraIssue, depths map[string]int, server string) GraphView {
+	g := GraphView{Server: server, Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+
+	keys := make([]string, 0, len(issues))
+	for key := range issues {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return compareIssueKeys(keys[i], keys[j]) < 0 })
+
+	seen := map[GraphEdge]bool{}
+	for _, key := range keys {
+		issue := issues[key]
+		g.Nodes = append(g.Nodes, GraphNode{
+			Key:     key,
+			Summary: issue.Fields.Summary,
+			Status:  nameOrEmpty(issue.Fields.Status),
+			Type:    nameOrEmpty(issue.Fields.IssueType),
+			Depth:   depths[key],
+			URL:     server + "/browse/" + key,
+		})
+		for _, e := range issueGraphEdges(issue) {
+			_, fromOK := issues[e.From]
+			_, toOK := issues[e.To]
+			if !fromOK || !toOK || seen[e] {
+				continue
+			}
+			seen[e] = true
+			g.Edges = append(g.Edges, e)
+		}
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if c := compareIssueKeys(g.Edges[i].From, g.Edges[j].From); c != 0 {
+			return c < 0
+		}
+		if c := compareIssueKeys(g.Edges[i].To, g.Edges[j].To); c != 0 {
+			return c < 0
+		}
+		return g.Edges[i].Type < g.Edges[j].Type
+	})
+
+	g.Cycles, g.Order, g.CriticalPath = analyzeDependencies(keys, g.Edges)
+	return g
+}
+
+// ---------------------------------------------------------------------------
+// Ordering analysis
+// ---------------------------------------------------------------------------
+
+// analyzeDependencies treats "A blocks B" and "child before parent" as
+// finish-before constraints. It returns the cycles (strongly connected
+// components), a topological order of the remaining issues and the longest
+// chain of constraints among them.
+func analyzeDependencies(keys []string, edges []GraphEdge) ([][]string, []string, []string) {
+	succ := map[string][]string{}
+	for _, e := range edges {
+		from, to := e.From, e.To
+		if e.Type != "blocks" {
+			// The parent finishes after its children.
+			from, to = e.To, e.From
+		}
+		succ[from] = append(succ[from], to)
+	}
+	for k := range succ {
+		sort.Slice(succ[k], func(i, j int) bool { return compareIssueKeys(succ[k][i], succ[k][j]) < 0 })
+	}
+
+	cycles := [][]string{}
+	inCycle := map[string]bool{}
+	for _, scc := range stronglyConnected(keys, succ) {
+		selfLoop := len(scc) == 1 && containsString(succ[scc[0]], scc[0])
+		if len(scc) > 1 || selfLoop {
+			sort.Slice(scc, func(i, j int) bool { return compareIssueKeys(scc[i], scc[j]) < 0 })
+			cycles = append(cycles, scc)
+			for _, k := range scc {
+				inCycle[k] = true
+			}
+		}
+	}
+
+	// Kahn's algorithm over the acyclic part, always taking the smallest key
+	// first so the order is stable.
+	indegree := map[string]int{}
+	for _, k := range keys {
+		if inCycle[k] {
+			continue
+		}
+		for _, n := range succ[k] {
+			if !inCycle[n] {
+				indegree[n]++
+			}
+		}
+	}
+	var ready []string
+	for _, k := range keys {
+		if !inCycle[k] && indegree[k] == 0 {
+			ready = append(ready, k)
+		}
+	}
+	order := []string{}
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool { return compareIssueKeys(ready[i], ready[j]) < 0 })
+		k := ready[0]
+		ready = ready[1:]
+		order = append(order, k)
+		for _, n := range succ[k] {
+			if inCycle[n] {
+				continue
+			}
+			indegree[n]--
+			if indegree[n] == 0 {
+				ready = append(ready, n)
+			}
+		}
+	}
+
+	// Longest path by issue count, walking the topological order backwards.
+	longest := map[string]int{}
+	nextOnPath := map[string]string{}
+	for i := len(order) - 1; i >= 0; i-- {
+		k := order[i]
+		longest[k] = 1
+		for _, n := range succ[k] {
+			if inCycle[n] {
+				continue
+			}
+			if longest[n]+1 > longest[k] {
+				longest[k] = longest[n] + 1
+				nextOnPath[k] = n
+			}
+		}
+	}
+	critical := []string{}
+	start := ""
+	for _, k := range order {
+		if start == "" || longest[k] > longest[start] {
+			start = k
+		}
+	}
+	if start != "" && longest[start] > 1 {
+		for k := start; k != ""; k = nextOnPath[k] {
+			critical = append(critical, k)
+		}
+	}
+
+	return cycles, order, critical
+}
+
+// stronglyConnected returns the strongly connected components of the graph
+// using Tarjan's algorithm.
+func stronglyConnected(keys []string, succ map[string][]string) [][]string {
+	index := 0
+	indexes := map[string]int{}
+	lowlink := map[string]int{}
+	onStack := map[string]bool{}
+	var stack []string
+	var components [][]string
+
+	var visit func(k string)
+	visit = func(k string) {
+		indexes[k] = index
+		lowlink[k] = index
+		index++
+		stack = append(stack, k)
+		onStack[k] = true
+
+		for _, n := range succ[k] {
+			if _, seen := indexes[n]; !seen {
+				visit(n)
+				lowlink[k] = minInt(lowlink[k], lowlink[n])
+			} else if onStack[n] {
+				lowlink[k] = minInt(lowlink[k], indexes[n])
+			}
+		}
+
+		if lowlink[k] == indexes[k] {
+			var scc []string
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				scc = append(scc, top)
+				if top == k {
+					break
+				}
+			}
+			components = append(components, scc)
+		}
+	}
+
+	for _, k := range keys {
+		if _, seen := indexes[k]; !seen {
+			visit(k)
+		}
+	}
+	return components
+}
+
+// ---------------------------------------------------------------------------
+// Renderers
+// ---------------------------------------------------------------------------
+
+func graphToDot(g GraphView) string {
+	critical := criticalEdgeSet(g.CriticalPath)
+
+	var sb strings.Builder
+	sb.WriteString("digraph issues {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+	for _, n := range g.Nodes {
+		label := fmt.Sprintf("%s\\n%s\\n[%s]", n.Key, n.Summary, n.Status)
+		fmt.Fprintf(&sb, "  %q [label=%s, URL=%q];\n", n.Key, dotQuote(label), n.URL)
+	}
+	for _, e := range g.Edges {
+		attrs := []string{"label=" + dotQuote(e.Type)}
+		if e.Type != "blocks" {
+			attrs = append(attrs, "style=dashed")
+		}
+		if critical[[2]string{e.From, e.To}] {
+			attrs = append(attrs, "color=red", "penwidth=2")
+		}
+		fmt.Fprintf(&sb, "  %q -> %q [%s];\n", e.From, e.To, strings.Join(attrs, ", "))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func graphToMermaid(g GraphView) string {
+	critical := criticalEdgeSet(g.CriticalPath)
+
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	for _, n := range g.Nodes {
+		label := fmt.Sprintf("%s: %s [%s]", n.Key, n.Summary, n.Status)
+		fmt.Fprintf(&sb, "  %s[\"%s\"]\n", mermaidID(n.Key), mermaidEscape(label))
+	}
+	var criticalLinks []string
+	for i, e := range g.Edges {
+		arrow := "-->"
+		if e.Type != "blocks" {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&sb, "  %s %s|%s| %s\n", mermaidID(e.From), arrow, e.Type, mermaidID(e.To))
+		if critical[[2]string{e.From, e.To}] {
+			criticalLinks = append(criticalLinks, fmt.Sprintf("%d", i))
+		}
+	}
+	if len(criticalLinks) > 0 {
+		fmt.Fprintf(&sb, "  linkStyle %s stroke:#d33,stroke-width:3px\n", strings.Join(criticalLinks, ","))
+	}
+	return sb.String()
+}
+
+// criticalEdgeSet returns the graph edges on the critical path. Parent edges
+// run against the dependency direction, so both orientations are included.
+func criticalEdgeSet(path []string) map[[2]string]bool {
+	set := map[[2]string]bool{}
+	for i := 0; i+1 < len(path); i++ {
+		set[[2]string{path[i], path[i+1]}] = true
+		set[[2]string{path[i+1], path[i]}] = true
+	}
+	return set
+}
+
+// dotQuote quotes s as a DOT string. Backslashes are escaped too, or a
+// trailing one would escape the closing quote.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+func mermaidID(key string) string {
+	return strings.NewReplacer("-", "_", ".", "_", " ", "_").Replace(key)
+}
+
+func mermaidEscape(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;").Replace(s)
+}
+
+// ---------------------------------------------------------------------------
+// Graph helpers
+// ---------------------------------------------------------------------------
+
+// keysJQL builds a "key in (...)" clause for the given issue keys.
+func keysJQL(keys []string) string {
+	return "key in (" + strings.Join(keys, ", ") + ")"
+}
+
+func chunkStrings(items []string, size int) [][]string {
+	var chunks [][]string
+	for start := 0; start < len(items); start += size {
+		chunks = append(chunks, items[start:minInt(start+size, len(items))])
+	}
+	return chunks
+}
+
+func containsString(items []string, s string) bool {
+	for _, item := range items {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeDependenciesOrdersAndFindsCycles(t *testing.T) {
+	keys := []string{"PROJ-1", "PROJ-2", "PROJ-3", "PROJ-4", "PROJ-5", "PROJ-10"}
+	edges := []GraphEdge{
+		{From: "PROJ-1", To: "PROJ-2", Type: "blocks"},
+		{From: "PROJ-2", To: "PROJ-3", Type: "blocks"},
+		{From: "PROJ-4", To: "PROJ-5", Type: "blocks"},
+		{From: "PROJ-5", To: "PROJ-4", Type: "blocks"},
+		{From: "PROJ-10", To: "PROJ-3", Type: "epic"},
+	}
+
+	cycles, order, critical := analyzeDependencies(keys, edges)
+
+	if want := [][]string{{"PROJ-4", "PROJ-5"}}; !reflect.DeepEqual(cycles, want) {
+		t.Fatalf("expected cycles %v, got %v", want, cycles)
+	}
+	if want := []string{"PROJ-1", "PROJ-2", "PROJ-3", "PROJ-10"}; !reflect.DeepEqual(order, want) {
+		t.Fatalf("expected order %v, got %v", want, order)
+	}
+	if want := []string{"PROJ-1", "PROJ-2", "PROJ-3", "PROJ-10"}; !reflect.DeepEqual(critical, want) {
+		t.Fatalf("expected critical path %v, got %v", want, critical)
+	}
+}
+
+func TestIssueGraphEdgesFromLinksAndParent(t *testing.T) {
+	blocks := JiraIssueLinkType{Name: "Blocks", Outward: "blocks", Inward: "is blocked by"}
+	relates := JiraIssueLinkType{Name: "Relates", Outward: "relates to", Inward: "relates to"}
+	issue := JiraIssue{Key: "PROJ-2", Fields: JiraIssueFields{
+		IssueLinks: []JiraIssueLink{
+			{Type: blocks, InwardIssue: &JiraLinkedIssue{Key: "PROJ-1"}},
+			{Type: relates, OutwardIssue: &JiraLinkedIssue{Key: "PROJ-9"}},
+		},
+		Parent: &JiraLinkedIssue{Key: "PROJ-100", Fields: JiraLinkedIssueFields{IssueType: &JiraNameField{Name: "Epic"}}},
+	}}
+
+	got := issueGraphEdges(issue)
+	want := []GraphEdge{
+		{From: "PROJ-1", To: "PROJ-2", Type: "blocks"},
+		{From: "PROJ-100", To: "PROJ-2", Type: "epic"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected edges %v, got %v", want, got)
+	}
+
+	g := GraphView{
+		Nodes: []GraphNode{{Key: "PROJ-1", Summary: `Say "hi"`}, {Key: "PROJ-2"}},
+		Edges: []GraphEdge{{From: "PROJ-1", To: "PROJ-2", Type: "blocks"}},
+	}
+	out := graphToMermaid(g)
+	if !containsAll(out, []string{"PROJ_1 -->|blocks| PROJ_2", "#quot;hi#quot;"}) || strings.Contains(out, `"hi"`) {
+		t.Fatalf("unexpected mermaid output:\n%s", out)
+	}
+}
+
+func TestDotQuoteEscapesBackslashesAndQuotes(t *testing.T) {
+	cases := map[string]string{
+		`Fix C:\temp\`:   `"Fix C:\\temp\\"`,
+		`Say "hi"`:       `"Say \"hi\""`,
+		"two\nlines":     `"two\nlines"`,
+		`already \" odd`: `"already \\\" odd"`,
+	}
+	for in, want := range cases {
+		if got := dotQuote(in); got != want {
+			t.Fatalf("dotQuote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
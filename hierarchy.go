@@ -0,0 +1,283 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// maxTreeDepth bounds recursive child lookups (epic > story > subtask is 3).
+const maxTreeDepth = 10
+
+// ---------------------------------------------------------------------------
+// Jira API response types
+// ---------------------------------------------------------------------------
+
+type JiraIssueTypesResponse struct {
+	IssueTypes []JiraIssueType `json:"issueTypes"`
+}
+
+type JiraIssueType struct {
+	ID             string `json:"id"`
+	Name           string `json:"name"`
+	Subtask        bool   `json:"subtask"`
+	HierarchyLevel int    `json:"hierarchyLevel"`
+}
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type IssueTreeNode struct {
+	IssueView
+	Children []IssueTreeNode `json:"children,omitempty"`
+}
+
+type SetParentResult struct {
+	Key    string `json:"key"`
+	Parent string `json:"parent"`
+	DryRun bool   `json:"dry_run,omitempty"`
+	URL    string `json:"url"`
+}
+
+// ---------------------------------------------------------------------------
+// Hierarchy commands
+// ---------------------------------------------------------------------------
+
+func runIssuesChildren(args []string) error {
+	fs := flag.NewFlagSet("issues children", flag.ContinueOnError)
+	recursive := fs.Bool("recursive", false, "include grandchildren (e.g. subtasks of an epic's stories)")
+	limit := fs.Int("limit", 200, "max issues to load")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("issue key is required (e.g. jiractl issues children PROJ-10)")
+	}
+	if *limit <= 0 {
+		return errors.New("--limit must be greater than 0")
+	}
+	issueKey := strings.ToUpper(positional[0])
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	root, err := getIssue(cfg, issueKey)
+	if err != nil {
+		return err
+	}
+
+	depth := 1
+	if *recursive {
+		depth = maxTreeDepth
+	}
+	tree, err := loadIssueTree(cfg, root, depth, *limit)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		return printJSON(tree)
+	}
+
+	printIssueTree(tree, "", true, true)
+	return nil
+}
+
+func runIssuesSetParent(args []string) error {
+	fs := flag.NewFlagSet("issues set-parent", flag.ContinueOnError)
+	parent := fs.String("parent", "", "new parent issue or epic key (required)")
+	dryRun := fs.Bool("dry-run", false, "show the change without calling Jira")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("issue key is required (e.g. jiractl issues set-parent PROJ-11 --parent PROJ-10)")
+	}
+	if *parent == "" {
+		return errors.New("--parent is required")
+	}
+	issueKey := strings.ToUpper(positional[0])
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	result, err := setIssueParent(cfg, issueKey, *parent, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		return printJSON(result)
+	}
+	if *dryRun {
+		fmt.Printf("Would move %s under %s\n", result.Key, result.Parent)
+		return nil
+	}
+	fmt.Printf("%s moved under %s\n", result.Key, result.Parent)
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Jira API calls
+// ---------------------------------------------------------------------------
+
+func getProjectIssueTypes(cfg Config, projectKey string) ([]JiraIssueType, error) {
+	return cachedGet(cfg, "issuetypes", projectKey, func() ([]JiraIssueType, error) {
+		var resp JiraIssueTypesResponse
+		path := "/rest/api/3/issue/createmeta/" + url.PathEscape(projectKey) + "/issuetypes"
+		if err := jiraDo(cfg, http.MethodGet, path, nil, nil, &resp); err != nil {
+			return nil, err
+		}
+		return resp.IssueTypes, nil
+	})
+}
+
+// loadIssueTree loads children level by level with "parent in (...)", which
+// covers both subtasks and epic children.
+func loadIssueTree(cfg Config, root JiraIssue, depth, limit int) (IssueTreeNode, error) {
+	byParent := map[string][]JiraIssue{}
+	loaded := 1
+	level := []string{root.Key}
+	seen := map[string]bool{root.Key: true}
+
+	for d := 0; d < depth && len(level) > 0 && loaded < limit; d++ {
+		var next []string
+		for _, chunk := range chunkStrings(level, 100) {
+			jql := "parent in (" + strings.Join(chunk, ", ") + ") ORDER BY key ASC"
+			result, err := searchIssuesWithFields(cfg, jql, limit-loaded, issueSearchFields+",parent")
+			if err != nil {
+				return IssueTreeNode{}, err
+			}
+			for _, child := range result.Issues {
+				if seen[child.Key] || child.Fields.Parent == nil {
+					continue
+				}
+				seen[child.Key] = true
+				byParent[child.Fields.Parent.Key] = append(byParent[child.Fields.Parent.Key], child)
+				next = append(next, child.Key)
+				loaded++
+			}
+		}
+		level = next
+	}
+
+	var build func(issue JiraIssue) IssueTreeNode
+	build = func(issue JiraIssue) IssueTreeNode {
+		node := IssueTreeNode{IssueView: issueToView(issue, cfg.Server)}
+		children := byParent[issue.Key]
+		sort.Slice(children, func(i, j int) bool { return compareIssueKeys(children[i].Key, children[j].Key) < 0 })
+		for _, child := range children {
+			node.Children = append(node.Children, build(child))
+		}
+		return node
+	}
+	return build(root), nil
+}
+
+// ---------------------------------------------------------------------------
+// Hierarchy helpers
+// ---------------------------------------------------------------------------
+
+// setIssueParent moves an issue under parent, an epic or a parent issue.
+func setIssueParent(cfg Config, issueKey, parent string, dryRun bool) (SetParentResult, error) {
+	parentKey := strings.ToUpper(strings.TrimSpace(parent))
+	if parentKey == "" {
+		return SetParentResult{}, errors.New("parent is required")
+	}
+	if !dryRun {
+		fields := map[string]any{"parent": map[string]string{"key": parentKey}}
+		if err := editIssue(cfg, issueKey, fields, nil); err != nil {
+			return SetParentResult{}, err
+		}
+	}
+	return SetParentResult{
+		Key:    issueKey,
+		Parent: parentKey,
+		DryRun: dryRun,
+		URL:    cfg.Server + "/browse/" + issueKey,
+	}, nil
+}
+
+// defaultIssueType picks the issue type for "issues create" when --type is
+// omitted: Task at the top level or under an epic, the project's subtask
+// type under any other parent.
+func defaultIssueType(cfg Config, projectKey, parentKey string) (string, error) {
+	if parentKey == "" {
+		return "Task", nil
+	}
+	parent, err := getIssue(cfg, parentKey)
+	if err != nil {
+		return "", err
+	}
+	if strings.EqualFold(nameOrEmpty(parent.Fields.IssueType), "Epic") {
+		return "Task", nil
+	}
+	types, err := getProjectIssueTypes(cfg, projectKey)
+	if err != nil {
+		return "", err
+	}
+	for _, t := range types {
+		if t.Subtask {
+			return t.Name, nil
+		}
+	}
+	return "", fmt.Errorf("project %s has no subtask issue type; pass --type", projectKey)
+}
+
+func printIssueTree(node IssueTreeNode, prefix string, root, last bool) {
+	line := fmt.Sprintf("%s [%s] %s (%s)", node.Key, node.Status, node.Summary, node.Type)
+	childPrefix := prefix
+	switch {
+	case root:
+		fmt.Println(line)
+	case last:
+		fmt.Printf("%s└── %s\n", prefix, line)
+		childPrefix += "    "
+	default:
+		fmt.Printf("%s├── %s\n", prefix, line)
+		childPrefix += "│   "
+	}
+	for i, child := range node.Children {
+		printIssueTree(child, childPrefix, false, i == len(node.Children)-1)
+	}
+}
+
+func linkedIssueToRef(issue JiraLinkedIssue) IssueRefView {
+	return IssueRefView{
+		Key:     issue.Key,
+		Summary: issue.Fields.Summary,
+		Status:  nameOrEmpty(issue.Fields.Status),
+		Type:    nameOrEmpty(issue.Fields.IssueType),
+	}
+}
+
+func issueToRef(issue JiraIssue) IssueRefView {
+	return IssueRefView{
+		Key:     issue.Key,
+		Summary: issue.Fields.Summary,
+		Status:  nameOrEmpty(issue.Fields.Status),
+		Type:    nameOrEmpty(issue.Fields.IssueType),
+	}
+}
+
+func hasIssueRef(refs []IssueRefView, key string) bool {
+	for _, r := range refs {
+		if r.Key == key {
+			return true
+		}
+	}
+	return false
+}
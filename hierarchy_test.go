@@ -0,0 +1,67 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLoadIssueTreeNestsChildrenByParent(t *testing.T) {
+	child := func(key, parent string) JiraIssue {
+		return JiraIssue{Key: key, Fields: JiraIssueFields{Parent: &JiraLinkedIssue{Key: parent}}}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		switch jql := r.URL.Query().Get("jql"); jql {
+		case "parent in (PROJ-10) ORDER BY key ASC":
+			writeJSON(t, w, JiraSearchResponse{Total: 2, Issues: []JiraIssue{child("PROJ-12", "PROJ-10"), child("PROJ-11", "PROJ-10")}})
+		case "parent in (PROJ-12, PROJ-11) ORDER BY key ASC":
+			writeJSON(t, w, JiraSearchResponse{Total: 1, Issues: []JiraIssue{child("PROJ-13", "PROJ-11")}})
+		default:
+			writeJSON(t, w, JiraSearchResponse{})
+		}
+	})
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	tree, err := loadIssueTree(cfg, JiraIssue{Key: "PROJ-10"}, maxTreeDepth, 100)
+	if err != nil {
+		t.Fatalf("loadIssueTree returned error: %v", err)
+	}
+
+	if got := len(tree.Children); got != 2 {
+		t.Fatalf("expected 2 children, got %d", got)
+	}
+	if tree.Children[0].Key != "PROJ-11" || tree.Children[1].Key != "PROJ-12" {
+		t.Fatalf("expected children sorted by key, got %s, %s", tree.Children[0].Key, tree.Children[1].Key)
+	}
+	if got := tree.Children[0].Children; len(got) != 1 || got[0].Key != "PROJ-13" {
+		t.Fatalf("expected PROJ-13 under PROJ-11, got %+v", got)
+	}
+}
+
+func TestShowIssueSurvivesFailedChildrenLookup(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/PROJ-1", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, JiraIssue{Key: "PROJ-1", Fields: JiraIssueFields{Summary: "Epic"}})
+	})
+	mux.HandleFunc("/rest/api/3/issue/PROJ-1/comment", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, JiraCommentsResponse{})
+	})
+	mux.HandleFunc("/rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("JIRACTL_SERVER", ts.URL)
+	t.Setenv("JIRACTL_EMAIL", "user@example.com")
+	t.Setenv("JIRACTL_API_TOKEN", "token")
+
+	if err := showIssue("PROJ-1", 20, false, true); err != nil {
+		t.Fatalf("expected the view to succeed without children, got %v", err)
+	}
+}
@@ -0,0 +1,713 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// This file implements the subset of JQL that the offline mirror can answer:
+// AND/OR/NOT with parentheses; =, !=, IN, NOT IN, IS [NOT] EMPTY on the
+// fields below; ~ and !~ on text fields; <, <=, >, >= on dates; and
+// ORDER BY. Anything else is rejected rather than silently ignored.
+
+// localJQLFields maps accepted field names to the canonical field.
+var localJQLFields = map[string]string{
+	"project":        "project",
+	"key":            "key",
+	"issuekey":       "key",
+	"status":         "status",
+	"statuscategory": "statuscategory",
+	"assignee":       "assignee",
+	"reporter":       "reporter",
+	"type":           "type",
+	"issuetype":      "type",
+	"priority":       "priority",
+	"labels":         "labels",
+	"label":          "labels",
+	"component":      "component",
+	"components":     "component",
+	"fixversion":     "fixversion",
+	"resolution":     "resolution",
+	"parent":         "parent",
+	"summary":        "summary",
+	"description":    "description",
+	"comment":        "comment",
+	"text":           "text",
+	"created":        "created",
+	"createddate":    "created",
+	"updated":        "updated",
+	"updateddate":    "updated",
+}
+
+var (
+	localTextFields = map[string]bool{"summary": true, "description": true, "comment": true, "text": true}
+	localDateFields = map[string]bool{"created": true, "updated": true}
+)
+
+// priorityRank orders the default priority scheme for ORDER BY priority.
+var priorityRank = map[string]int{"highest": 5, "high": 4, "medium": 3, "low": 2, "lowest": 1}
+
+var relativeDatePattern = regexp.MustCompile(`^([-+]?)(\d+)([wdhm])$`)
+
+// ---------------------------------------------------------------------------
+// Query types
+// ---------------------------------------------------------------------------
+
+type localQuery struct {
+	where jqlExpr
+	order []jqlOrder
+}
+
+type jqlOrder struct {
+	field string
+	desc  bool
+}
+
+// jqlEnv carries what functions and relative dates resolve against.
+type jqlEnv struct {
+	me  *JiraUser
+	now time.Time
+}
+
+type jqlExpr interface {
+	eval(env jqlEnv, mi *MirrorIssue) bool
+}
+
+type jqlAnd struct{ left, right jqlExpr }
+type jqlOr struct{ left, right jqlExpr }
+type jqlNot struct{ expr jqlExpr }
+
+type jqlValue struct {
+	text string
+	// fn is a lower-cased function name such as "currentuser" or "now".
+	fn string
+}
+
+type jqlClause struct {
+	field  string
+	op     string
+	values []jqlValue
+	// dates holds the parsed values of date clauses.
+	dates []time.Time
+}
+
+func (e jqlAnd) eval(env jqlEnv, mi *MirrorIssue) bool {
+	return e.left.eval(env, mi) && e.right.eval(env, mi)
+}
+
+func (e jqlOr) eval(env jqlEnv, mi *MirrorIssue) bool {
+	return e.left.eval(env, mi) || e.right.eval(env, mi)
+}
+
+func (e jqlNot) eval(env jqlEnv, mi *MirrorIssue) bool {
+	return !e.expr.eval(env, mi)
+}
+
+// ---------------------------------------------------------------------------
+// Tokenizer
+// ---------------------------------------------------------------------------
+
+type jqlTokenKind int
+
+const (
+	tokWord jqlTokenKind = iota
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+type jqlToken struct {
+	kind jqlTokenKind
+	text string
+}
+
+func tokenizeJQL(s string) ([]jqlToken, error) {
+	var tokens []jqlToken
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, jqlToken{tokLParen, "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, jqlToken{tokRParen, ")"})
+			i++
+		case c == ',':
+			tokens = append(tokens, jqlToken{tokComma, ","})
+			i++
+		case c == '"' || c == '\'':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(s) && s[j] != c; j++ {
+				if s[j] == '\\' && j+1 < len(s) {
+					j++
+				}
+				b.WriteByte(s[j])
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("unterminated string starting at offset %d", i)
+			}
+			tokens = append(tokens, jqlToken{tokString, b.String()})
+			i = j + 1
+		case strings.ContainsRune("=!<>~", rune(c)):
+			op := string(c)
+			if i+1 < len(s) && (s[i+1] == '=' || (c == '!' && s[i+1] == '~')) {
+				op += string(s[i+1])
+			}
+			if op == "!" {
+				return nil, fmt.Errorf("unexpected %q at offset %d", op, i)
+			}
+			tokens = append(tokens, jqlToken{tokOp, op})
+			i += len(op)
+		default:
+			j := i
+			for j < len(s) && !strings.ContainsRune(" \t\n\r(),\"'=!<>~", rune(s[j])) {
+				j++
+			}
+			tokens = append(tokens, jqlToken{tokWord, s[i:j]})
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+// ---------------------------------------------------------------------------
+// Parser
+// ---------------------------------------------------------------------------
+
+type jqlParser struct {
+	tokens []jqlToken
+	pos    int
+	now    time.Time
+}
+
+// parseLocalJQL parses a query in the supported JQL subset.
+func parseLocalJQL(s string, now time.Time) (localQuery, error) {
+	tokens, err := tokenizeJQL(s)
+	if err != nil {
+		return localQuery{}, err
+	}
+	p := &jqlParser{tokens: tokens, now: now}
+
+	var q localQuery
+	if !p.atEnd() && !p.keyword("order") {
+		if q.where, err = p.parseOr(); err != nil {
+			return localQuery{}, err
+		}
+	}
+	if p.keyword("order") {
+		p.pos++
+		if !p.keyword("by") {
+			return localQuery{}, fmt.Errorf("expected BY after ORDER")
+		}
+		p.pos++
+		if q.order, err = p.parseOrder(); err != nil {
+			return localQuery{}, err
+		}
+	}
+	if !p.atEnd() {
+		return localQuery{}, fmt.Errorf("unexpected %q", p.peek().text)
+	}
+	return q, nil
+}
+
+func (p *jqlParser) atEnd() bool { return p.pos >= len(p.tokens) }
+
+func (p *jqlParser) peek() jqlToken {
+	if p.atEnd() {
+		return jqlToken{kind: -1}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *jqlParser) keyword(k string) bool {
+	t := p.peek()
+	return t.kind == tokWord && strings.EqualFold(t.text, k)
+}
+
+func (p *jqlParser) parseOr() (jqlExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = jqlOr{left, right}
+	}
+	return left, nil
+}
+
+func (p *jqlParser) parseAnd() (jqlExpr, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		p.pos++
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = jqlAnd{left, right}
+	}
+	return left, nil
+}
+
+func (p *jqlParser) parseFactor() (jqlExpr, error) {
+	switch {
+	case p.keyword("not"):
+		p.pos++
+		expr, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		return jqlNot{expr}, nil
+	case p.peek().kind == tokLParen:
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokRParen {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return expr, nil
+	}
+	return p.parseClause()
+}
+
+func (p *jqlParser) parseClause() (jqlExpr, error) {
+	t := p.peek()
+	if t.kind != tokWord && t.kind != tokString {
+		return nil, fmt.Errorf("expected a field name, got %q", t.text)
+	}
+	p.pos++
+	field, ok := localJQLFields[strings.ToLower(t.text)]
+	if !ok {
+		return nil, fmt.Errorf("field %q is not supported offline; supported: %s", t.text, strings.Join(sortedKeys(localJQLFields), ", "))
+	}
+	c := &jqlClause{field: field}
+
+	switch {
+	case p.peek().kind == tokOp:
+		c.op = p.peek().text
+		p.pos++
+	case p.keyword("in"):
+		c.op = "in"
+		p.pos++
+	case p.keyword("not"):
+		p.pos++
+		if !p.keyword("in") {
+			return nil, fmt.Errorf("expected IN after NOT for %s", t.text)
+		}
+		c.op = "not in"
+		p.pos++
+	case p.keyword("is"):
+		p.pos++
+		c.op = "is"
+		if p.keyword("not") {
+			c.op = "is not"
+			p.pos++
+		}
+		if !p.keyword("empty") && !p.keyword("null") {
+			return nil, fmt.Errorf("expected EMPTY after %s", strings.ToUpper(c.op))
+		}
+		p.pos++
+		return c, nil
+	default:
+		return nil, fmt.Errorf("expected an operator after %s", t.text)
+	}
+
+	if c.op == "in" || c.op == "not in" {
+		if p.peek().kind != tokLParen {
+			return nil, fmt.Errorf("expected ( after %s", strings.ToUpper(c.op))
+		}
+		p.pos++
+		for {
+			v, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			c.values = append(c.values, v)
+			if p.peek().kind == tokComma {
+				p.pos++
+				continue
+			}
+			if p.peek().kind != tokRParen {
+				return nil, fmt.Errorf("expected , or ) in %s list", strings.ToUpper(c.op))
+			}
+			p.pos++
+			break
+		}
+	} else {
+		v, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		c.values = []jqlValue{v}
+	}
+
+	return c, p.validateClause(c, t.text)
+}
+
+func (p *jqlParser) parseValue() (jqlValue, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokString:
+		p.pos++
+		return jqlValue{text: t.text}, nil
+	case tokWord:
+		p.pos++
+		if p.peek().kind == tokLParen {
+			p.pos++
+			if p.peek().kind != tokRParen {
+				return jqlValue{}, fmt.Errorf("function arguments are not supported offline: %s(...)", t.text)
+			}
+			p.pos++
+			return jqlValue{fn: strings.ToLower(t.text)}, nil
+		}
+		return jqlValue{text: t.text}, nil
+	}
+	return jqlValue{}, fmt.Errorf("expected a value, got %q", t.text)
+}
+
+func (p *jqlParser) validateClause(c *jqlClause, name string) error {
+	switch c.op {
+	case "~", "!~":
+		if !localTextFields[c.field] {
+			return fmt.Errorf("%s does not support %s offline (text fields only: summary, description, comment, text)", name, c.op)
+		}
+	case "<", "<=", ">", ">=":
+		if !localDateFields[c.field] {
+			return fmt.Errorf("%s does not support %s offline (dates only: created, updated)", name, c.op)
+		}
+	default:
+		if localTextFields[c.field] && c.field != "summary" {
+			return fmt.Errorf("%s only supports ~ and !~", name)
+		}
+	}
+
+	for _, v := range c.values {
+		switch v.fn {
+		case "":
+		case "currentuser":
+			if c.field != "assignee" && c.field != "reporter" {
+				return fmt.Errorf("currentUser() only applies to assignee and reporter")
+			}
+		case "now", "startofday":
+			if !localDateFields[c.field] {
+				return fmt.Errorf("%s() only applies to dates", v.fn)
+			}
+		default:
+			return fmt.Errorf("function %s() is not supported offline", v.fn)
+		}
+	}
+
+	if localDateFields[c.field] {
+		for _, v := range c.values {
+			t, err := parseJQLDate(v, p.now)
+			if err != nil {
+				return err
+			}
+			c.dates = append(c.dates, t)
+		}
+	}
+	return nil
+}
+
+func (p *jqlParser) parseOrder() ([]jqlOrder, error) {
+	var order []jqlOrder
+	for {
+		t := p.peek()
+		if t.kind != tokWord && t.kind != tokString {
+			return nil, fmt.Errorf("expected a field after ORDER BY")
+		}
+		p.pos++
+		field, ok := localJQLFields[strings.ToLower(t.text)]
+		if !ok {
+			return nil, fmt.Errorf("cannot order by %q offline", t.text)
+		}
+		o := jqlOrder{field: field}
+		if p.keyword("asc") {
+			p.pos++
+		} else if p.keyword("desc") {
+			o.desc = true
+			p.pos++
+		}
+		order = append(order, o)
+		if p.peek().kind != tokComma {
+			return order, nil
+		}
+		p.pos++
+	}
+}
+
+// parseJQLDate accepts "2024-01-31", "2024-01-31 14:00", relative offsets
+// such as "-7d" or "-2w", now() and startOfDay().
+func parseJQLDate(v jqlValue, now time.Time) (time.Time, error) {
+	switch v.fn {
+	case "now":
+		return now, nil
+	case "startofday":
+		y, m, d := now.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, now.Location()), nil
+	}
+	if m := relativeDatePattern.FindStringSubmatch(strings.ToLower(v.text)); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		unit := map[string]time.Duration{"w": 7 * 24 * time.Hour, "d": 24 * time.Hour, "h": time.Hour, "m": time.Minute}[m[3]]
+		d := time.Duration(n) * unit
+		if m[1] == "-" {
+			d = -d
+		}
+		return now.Add(d), nil
+	}
+	for _, layout := range []string{"2006-01-02", "2006/01/02", "2006-01-02 15:04", "2006/01/02 15:04"} {
+		if t, err := time.ParseInLocation(layout, v.text, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q (use 2006-01-02, \"2006-01-02 15:04\" or -7d)", v.text)
+}
+
+// ---------------------------------------------------------------------------
+// Evaluation
+// ---------------------------------------------------------------------------
+
+func (c *jqlClause) eval(env jqlEnv, mi *MirrorIssue) bool {
+	if localDateFields[c.field] {
+		return c.evalDate(mi)
+	}
+
+	actual := localFieldValues(mi, c.field)
+	switch c.op {
+	case "is":
+		return len(actual) == 0
+	case "is not":
+		return len(actual) > 0
+	case "~":
+		return textContains(strings.Join(actual, "\n"), c.values[0].text)
+	case "!~":
+		return !textContains(strings.Join(actual, "\n"), c.values[0].text)
+	}
+
+	matched := false
+	for _, v := range c.values {
+		for _, want := range c.expand(env, v) {
+			for _, a := range actual {
+				if strings.EqualFold(a, want) {
+					matched = true
+				}
+			}
+		}
+	}
+	switch c.op {
+	case "=", "in":
+		return matched
+	default: // "!=", "not in": like Jira, empty fields never match.
+		return len(actual) > 0 && !matched
+	}
+}
+
+func (c *jqlClause) evalDate(mi *MirrorIssue) bool {
+	raw := mi.Issue.Fields.Created
+	if c.field == "updated" {
+		raw = mi.Issue.Fields.Updated
+	}
+	if c.op == "is" || c.op == "is not" {
+		return (raw == "") == (c.op == "is")
+	}
+	t, err := parseJiraTime(raw)
+	if err != nil {
+		return false
+	}
+	for _, want := range c.dates {
+		var ok bool
+		switch c.op {
+		case "<":
+			ok = t.Before(want)
+		case "<=":
+			ok = !t.After(want)
+		case ">":
+			ok = t.After(want)
+		case ">=":
+			ok = !t.Before(want)
+		case "=", "in":
+			ok = t.Equal(want)
+		case "!=", "not in":
+			ok = !t.Equal(want)
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// expand resolves a value to the strings it may equal: currentUser() becomes
+// the mirrored account's ID.
+func (c *jqlClause) expand(env jqlEnv, v jqlValue) []string {
+	if v.fn == "currentuser" {
+		if env.me == nil {
+			return nil
+		}
+		return []string{env.me.AccountID}
+	}
+	return []string{v.text}
+}
+
+// localFieldValues returns the values a clause compares against; users match
+// on account ID, email or display name.
+func localFieldValues(mi *MirrorIssue, field string) []string {
+	f := mi.Issue.Fields
+	nonEmpty := func(values ...string) []string {
+		var out []string
+		for _, v := range values {
+			if v != "" {
+				out = append(out, v)
+			}
+		}
+		return out
+	}
+	names := func(items []JiraNameField) []string {
+		var out []string
+		for _, item := range items {
+			out = append(out, item.Name)
+		}
+		return out
+	}
+	user := func(u *JiraUser) []string {
+		if u == nil {
+			return nil
+		}
+		return nonEmpty(u.AccountID, u.EmailAddress, u.DisplayName)
+	}
+
+	switch field {
+	case "project":
+		project, _ := splitIssueKey(mi.Issue.Key)
+		return nonEmpty(project)
+	case "key":
+		return nonEmpty(mi.Issue.Key)
+	case "status":
+		return nonEmpty(nameOrEmpty(f.Status))
+	case "statuscategory":
+		return nonEmpty(mi.StatusCategory)
+	case "assignee":
+		return user(f.Assignee)
+	case "reporter":
+		return user(f.Reporter)
+	case "type":
+		return nonEmpty(nameOrEmpty(f.IssueType))
+	case "priority":
+		return nonEmpty(nameOrEmpty(f.Priority))
+	case "labels":
+		return f.Labels
+	case "component":
+		return names(f.Components)
+	case "fixversion":
+		return names(f.FixVersions)
+	case "resolution":
+		return nonEmpty(nameOrEmpty(f.Resolution))
+	case "parent":
+		if f.Parent == nil {
+			return nil
+		}
+		return nonEmpty(f.Parent.Key)
+	case "summary":
+		return nonEmpty(f.Summary)
+	case "description":
+		return nonEmpty(adfToText(f.Description))
+	case "comment":
+		var bodies []string
+		for _, c := range mi.Comments {
+			bodies = append(bodies, adfToText(c.Body))
+		}
+		return nonEmpty(bodies...)
+	case "text":
+		values := nonEmpty(f.Summary, adfToText(f.Description))
+		return append(values, localFieldValues(mi, "comment")...)
+	}
+	return nil
+}
+
+// textContains reports whether every word of query occurs in text, ignoring
+// case and trailing wildcards.
+func textContains(text, query string) bool {
+	text = strings.ToLower(text)
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return false
+	}
+	for _, w := range words {
+		if w = strings.Trim(w, "*?"); w != "" && !strings.Contains(text, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// ---------------------------------------------------------------------------
+// Query helpers
+// ---------------------------------------------------------------------------
+
+// run filters and orders issues; without ORDER BY issues are ordered by key.
+func (q localQuery) run(env jqlEnv, issues []MirrorIssue) []MirrorIssue {
+	var out []MirrorIssue
+	for i := range issues {
+		if q.where == nil || q.where.eval(env, &issues[i]) {
+			out = append(out, issues[i])
+		}
+	}
+	order := q.order
+	if len(order) == 0 {
+		order = []jqlOrder{{field: "key"}}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		for _, o := range order {
+			c := compareLocalField(&out[i], &out[j], o.field)
+			if c == 0 {
+				continue
+			}
+			if o.desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+	return out
+}
+
+func compareLocalField(a, b *MirrorIssue, field string) int {
+	switch field {
+	case "key":
+		return compareIssueKeys(a.Issue.Key, b.Issue.Key)
+	case "created", "updated":
+		ra, rb := a.Issue.Fields.Created, b.Issue.Fields.Created
+		if field == "updated" {
+			ra, rb = a.Issue.Fields.Updated, b.Issue.Fields.Updated
+		}
+		ta, _ := parseJiraTime(ra)
+		tb, _ := parseJiraTime(rb)
+		return ta.Compare(tb)
+	case "priority":
+		return priorityRank[strings.ToLower(nameOrEmpty(a.Issue.Fields.Priority))] -
+			priorityRank[strings.ToLower(nameOrEmpty(b.Issue.Fields.Priority))]
+	}
+	va := strings.ToLower(strings.Join(localFieldValues(a, field), ","))
+	vb := strings.ToLower(strings.Join(localFieldValues(b, field), ","))
+	return strings.Compare(va, vb)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLocalJQLFiltersAndOrders(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	me := &JiraUser{AccountID: "acc-1", EmailAddress: "me@example.com"}
+	issues := []MirrorIssue{
+		{Issue: JiraIssue{Key: "PROJ-10", Fields: JiraIssueFields{
+			Summary: "Login fails on Safari", Status: &JiraNameField{Name: "In Progress"},
+			Assignee: me, Labels: []string{"web"}, Updated: "2026-03-09T10:00:00.000+0000",
+		}}, StatusCategory: "In Progress"},
+		{Issue: JiraIssue{Key: "PROJ-9", Fields: JiraIssueFields{
+			Summary: "Crash in login form", Status: &JiraNameField{Name: "To Do"},
+			Assignee: me, Updated: "2026-03-01T10:00:00.000+0000",
+		}}, StatusCategory: "To Do"},
+		{Issue: JiraIssue{Key: "OPS-2", Fields: JiraIssueFields{
+			Summary: "Rotate login certificates", Status: &JiraNameField{Name: "Done"},
+			Updated: "2026-03-10T09:00:00.000+0000",
+		}}, StatusCategory: "Done"},
+	}
+	env := jqlEnv{me: me, now: now}
+
+	cases := map[string]string{
+		`assignee = currentUser() ORDER BY updated DESC`:                    "PROJ-10,PROJ-9",
+		`project = PROJ AND updated >= -3d`:                                 "PROJ-10",
+		`text ~ "login" AND statusCategory != Done`:                         "PROJ-9,PROJ-10",
+		`project in (PROJ, OPS) AND NOT (labels = web OR status = "To Do")`: "OPS-2",
+		`assignee is EMPTY`: "OPS-2",
+	}
+	for jql, want := range cases {
+		q, err := parseLocalJQL(jql, now)
+		if err != nil {
+			t.Fatalf("parseLocalJQL(%q) returned error: %v", jql, err)
+		}
+		var keys []string
+		for _, mi := range q.run(env, issues) {
+			keys = append(keys, mi.Issue.Key)
+		}
+		if got := strings.Join(keys, ","); got != want {
+			t.Errorf("%s: got %q, want %q", jql, got, want)
+		}
+	}
+}
+
+func TestLocalJQLRejectsUnsupportedSyntax(t *testing.T) {
+	for _, jql := range []string{
+		`sprint in openSprints()`,
+		`status = "Done`,
+		`project = PROJ AND`,
+		`assignee was me@example.com`,
+	} {
+		if _, err := parseLocalJQL(jql, time.Now()); err == nil {
+			t.Errorf("expected %q to be rejected", jql)
+		}
+	}
+}
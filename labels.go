@@ -0,0 +1,157 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type ValuesResult struct {
+	Key     string   `json:"key"`
+	Field   string   `json:"field"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	DryRun  bool     `json:"dry_run,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// ---------------------------------------------------------------------------
+// Label and component commands
+// ---------------------------------------------------------------------------
+
+// runIssuesValues implements "issues label" and "issues component". field is
+// the Jira field name: "labels" or "components".
+func runIssuesValues(args []string, field string) error {
+	name := "issues label"
+	if field == "components" {
+		name = "issues component"
+	}
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	var add, remove stringList
+	fs.Var(&add, "add", "value to add (repeatable or comma-separated)")
+	fs.Var(&remove, "remove", "value to remove (repeatable or comma-separated)")
+	dryRun := fs.Bool("dry-run", false, "show the changes without applying them")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	keys := issueKeysArg(positional)
+	if len(keys) == 0 {
+		return fmt.Errorf("at least one issue key is required (e.g. jiractl %s PROJ-1 --add VALUE)", name)
+	}
+	if err := validateValueOps(field, add, remove); err != nil {
+		return err
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	// Each issue is updated on its own so one failure doesn't stop the rest.
+	results := make([]ValuesResult, 0, len(keys))
+	failed := 0
+	for _, key := range keys {
+		result, err := updateIssueValues(cfg, key, field, add, remove, *dryRun)
+		if err != nil {
+			result.Error = err.Error()
+			failed++
+		}
+		results = append(results, result)
+	}
+
+	var failure error
+	if failed > 0 {
+		failure = fmt.Errorf("%d of %d issues failed", failed, len(keys))
+	}
+	if *jsonOut {
+		return printPartialJSON(map[string]any{"results": results}, failure)
+	}
+	for _, r := range results {
+		if r.Error != "" {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", r.Key, r.Error)
+			continue
+		}
+		prefix := "Updated"
+		if r.DryRun {
+			prefix = "Would update"
+		}
+		fmt.Printf("%s %s %s: %s\n", prefix, r.Key, field, valueChanges(r))
+	}
+	return failure
+}
+
+// ---------------------------------------------------------------------------
+// Label and component helpers
+// ---------------------------------------------------------------------------
+
+// updateIssueValues adds and removes labels or components with Jira's atomic
+// update operations, so values set concurrently by others are preserved.
+func updateIssueValues(cfg Config, issueKey, field string, add, remove []string, dryRun bool) (ValuesResult, error) {
+	result := ValuesResult{Key: issueKey, Field: field, Added: add, Removed: remove, DryRun: dryRun}
+	if dryRun {
+		return result, nil
+	}
+	update := map[string][]map[string]any{field: valueOps(field, add, remove)}
+	return result, editIssue(cfg, issueKey, nil, update)
+}
+
+// valueChanges renders a result as "+added -removed".
+func valueChanges(r ValuesResult) string {
+	var changes []string
+	for _, v := range r.Added {
+		changes = append(changes, "+"+v)
+	}
+	for _, v := range r.Removed {
+		changes = append(changes, "-"+v)
+	}
+	return strings.Join(changes, " ")
+}
+
+// valueOps builds the update operations for a field. Labels are plain
+// strings; components and fix versions are referenced by name.
+func valueOps(field string, add, remove []string) []map[string]any {
+	value := func(v string) any {
+		if field == "components" || field == "fixVersions" {
+			return map[string]string{"name": v}
+		}
+		return v
+	}
+	var ops []map[string]any
+	for _, v := range add {
+		ops = append(ops, map[string]any{"add": value(v)})
+	}
+	for _, v := range remove {
+		ops = append(ops, map[string]any{"remove": value(v)})
+	}
+	return ops
+}
+
+func validateValueOps(field string, add, remove []string) error {
+	if len(add) == 0 && len(remove) == 0 {
+		return errors.New("nothing to do: pass --add and/or --remove")
+	}
+	for _, a := range add {
+		for _, r := range remove {
+			if strings.EqualFold(a, r) {
+				return fmt.Errorf("%q is both added and removed", a)
+			}
+		}
+	}
+	if field == "labels" {
+		for _, v := range append(append([]string{}, add...), remove...) {
+			if strings.ContainsAny(v, " \t") {
+				return fmt.Errorf("label %q contains whitespace; Jira labels cannot contain spaces", v)
+			}
+		}
+	}
+	return nil
+}
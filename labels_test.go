@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestUpdateIssueValuesUsesUpdateOperations(t *testing.T) {
+	var body map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/rest/api/3/issue/PROJ-1" {
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	if _, err := updateIssueValues(cfg, "PROJ-1", "components", []string{"Backend"}, []string{"Legacy"}, false); err != nil {
+		t.Fatalf("updateIssueValues returned error: %v", err)
+	}
+
+	if _, ok := body["fields"]; ok {
+		t.Fatalf("expected no fields overwrite, got %v", body["fields"])
+	}
+	got, _ := json.Marshal(body["update"])
+	want := `{"components":[{"add":{"name":"Backend"}},{"remove":{"name":"Legacy"}}]}`
+	if string(got) != want {
+		t.Fatalf("update = %s, want %s", got, want)
+	}
+}
+
+func TestValidateValueOps(t *testing.T) {
+	if err := validateValueOps("labels", []string{"triage"}, []string{"needs-info"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := validateValueOps("labels", nil, nil); err == nil {
+		t.Fatalf("expected error with no operations")
+	}
+	if err := validateValueOps("labels", []string{"a"}, []string{"A"}); err == nil {
+		t.Fatalf("expected error when adding and removing the same value")
+	}
+	if err := validateValueOps("labels", []string{"needs info"}, nil); err == nil {
+		t.Fatalf("expected error for label with a space")
+	}
+	if err := validateValueOps("components", []string{"Web App"}, nil); err != nil {
+		t.Fatalf("components may contain spaces: %v", err)
+	}
+}
+
+func TestIssuesLabelPartialFailureWithJSONIsReportedOnce(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/rest/api/3/issue/PROJ-2" {
+			http.Error(w, `{"errorMessages":["Issue does not exist"]}`, http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("JIRACTL_SERVER", ts.URL)
+	t.Setenv("JIRACTL_EMAIL", "user@example.com")
+	t.Setenv("JIRACTL_API_TOKEN", "token")
+
+	err := runIssuesValues([]string{"PROJ-1", "PROJ-2", "--add", "urgent", "--json"}, "labels")
+	var reported reportedError
+	if !errors.As(err, &reported) {
+		t.Fatalf("expected a reportedError so main prints no second JSON document, got %v", err)
+	}
+}
+
+func TestPrintPartialJSONEnvelopeReportsFailure(t *testing.T) {
+	t.Setenv("JIRACTL_JSON_ENVELOPE", "1")
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	err = printPartialJSON(map[string]any{"results": []string{"PROJ-1"}}, errors.New("1 of 2 issues failed"))
+	os.Stdout = stdout
+	w.Close()
+	out, _ := io.ReadAll(r)
+
+	var reported reportedError
+	if !errors.As(err, &reported) {
+		t.Fatalf("expected a reportedError, got %v", err)
+	}
+	if !containsAll(string(out), []string{`"ok":false`, `"PARTIAL_FAILURE"`, `"results":["PROJ-1"]`}) {
+		t.Fatalf("unexpected envelope %s", out)
+	}
+}
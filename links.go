@@ -0,0 +1,397 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+)
+
+// ---------------------------------------------------------------------------
+// Jira API response types
+// ---------------------------------------------------------------------------
+
+type JiraIssueLink struct {
+	ID           string            `json:"id"`
+	Type         JiraIssueLinkType `json:"type"`
+	InwardIssue  *JiraLinkedIssue  `json:"inwardIssue"`
+	OutwardIssue *JiraLinkedIssue  `json:"outwardIssue"`
+}
+
+type JiraIssueLinkType struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Inward  string `json:"inward"`
+	Outward string `json:"outward"`
+}
+
+type JiraIssueLinkTypesResponse struct {
+	IssueLinkTypes []JiraIssueLinkType `json:"issueLinkTypes"`
+}
+
+// JiraLinkedIssue is the abbreviated issue embedded in links, subtasks and
+// parent references.
+type JiraLinkedIssue struct {
+	ID     string                `json:"id,omitempty"`
+	Key    string                `json:"key"`
+	Fields JiraLinkedIssueFields `json:"fields"`
+}
+
+type JiraLinkedIssueFields struct {
+	Summary   string         `json:"summary"`
+	Status    *JiraNameField `json:"status"`
+	IssueType *JiraNameField `json:"issuetype"`
+}
+
+type JiraCreateLinkRequest struct {
+	Type         JiraNameField `json:"type"`
+	InwardIssue  JiraIssueRef  `json:"inwardIssue"`
+	OutwardIssue JiraIssueRef  `json:"outwardIssue"`
+}
+
+type JiraIssueRef struct {
+	Key string `json:"key"`
+}
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type IssueLinkView struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Direction string `json:"direction"`
+	Relation  string `json:"relation"`
+	Key       string `json:"key"`
+	Status    string `json:"status"`
+	Summary   string `json:"summary"`
+}
+
+type LinkTypeView struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Outward string `json:"outward"`
+	Inward  string `json:"inward"`
+}
+
+type LinkResult struct {
+	From      string `json:"from"`
+	Relation  string `json:"relation"`
+	To        string `json:"to"`
+	Type      string `json:"type"`
+	MatchedBy string `json:"matched_by,omitempty"`
+	Warning   string `json:"warning,omitempty"`
+	DryRun    bool   `json:"dry_run,omitempty"`
+	URL       string `json:"url"`
+}
+
+type UnlinkResult struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// ---------------------------------------------------------------------------
+// Link commands
+// ---------------------------------------------------------------------------
+
+func printLinkTypesHelp() {
+	fmt.Println("jiractl link-types commands:")
+	fmt.Println("  link-types list  [--json]")
+}
+
+func runLinkTypes(args []string) error {
+	if len(args) == 0 {
+		printLinkTypesHelp()
+		return nil
+	}
+
+	switch args[0] {
+	case "list":
+		return runLinkTypesList(args[1:])
+	case "help", "--help", "-h":
+		printLinkTypesHelp()
+		return nil
+	default:
+		printLinkTypesHelp()
+		return fmt.Errorf("unknown link-types command %q", args[0])
+	}
+}
+
+func runLinkTypesList(args []string) error {
+	fs := flag.NewFlagSet("link-types list", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	types, err := getLinkTypes(cfg)
+	if err != nil {
+		return err
+	}
+
+	views := make([]LinkTypeView, 0, len(types))
+	for _, t := range types {
+		views = append(views, LinkTypeView{ID: t.ID, Name: t.Name, Outward: t.Outward, Inward: t.Inward})
+	}
+
+	if *jsonOut {
+		return printJSON(map[string]any{"link_types": views})
+	}
+
+	if len(views) == 0 {
+		fmt.Println("No link types found.")
+		return nil
+	}
+	fmt.Printf("Link types (%d):\n", len(views))
+	for _, v := range views {
+		fmt.Printf("- %-20s  outward: %-20s  inward: %s\n", v.Name, v.Outward, v.Inward)
+	}
+	return nil
+}
+
+func runIssuesLink(args []string) error {
+	fs := flag.NewFlagSet("issues link", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "resolve the link type without creating the link")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) < 3 {
+		return errors.New("usage: jiractl issues link PROJ-1 blocks PROJ-2")
+	}
+	from := strings.ToUpper(positional[0])
+	to := strings.ToUpper(positional[len(positional)-1])
+	relation := strings.Join(positional[1:len(positional)-1], " ")
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	result, err := linkIssues(cfg, from, relation, to, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		return printJSON(result)
+	}
+
+	if result.Warning != "" {
+		fmt.Fprintln(os.Stderr, "warning:", result.Warning)
+	}
+	if *dryRun {
+		fmt.Printf("Would link: %s %s %s\n", result.From, result.Relation, result.To)
+		return nil
+	}
+	fmt.Printf("Linked: %s %s %s\n", result.From, result.Relation, result.To)
+	return nil
+}
+
+func runIssuesUnlink(args []string) error {
+	fs := flag.NewFlagSet("issues unlink", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+
+	if len(positional) == 0 {
+		return errors.New("link ID is required (see the links section of: jiractl issues view PROJ-123)")
+	}
+	linkID := positional[0]
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := deleteIssueLink(cfg, linkID); err != nil {
+		return err
+	}
+
+	result := UnlinkResult{ID: linkID, Deleted: true}
+	if *jsonOut {
+		return printJSON(result)
+	}
+	fmt.Printf("Link %s deleted\n", linkID)
+	return nil
+}
+
+// linkIssues links from and to with the link type matching relation, e.g.
+// "blocks" or "is blocked by".
+func linkIssues(cfg Config, from, relation, to string, dryRun bool) (LinkResult, error) {
+	types, err := getLinkTypes(cfg)
+	if err != nil {
+		return LinkResult{}, err
+	}
+
+	match, matchedBy, warning, err := matchLinkType(types, relation)
+	if err != nil {
+		return LinkResult{}, err
+	}
+
+	// Jira renders a link as "<inwardIssue> <outward> <outwardIssue>", so an
+	// inward phrase ("is blocked by") swaps the two issues.
+	inward, outward := from, to
+	phrase := match.Type.Outward
+	if match.Inward {
+		inward, outward = to, from
+		phrase = match.Type.Inward
+	}
+
+	if !dryRun {
+		if err := createIssueLink(cfg, match.Type.Name, inward, outward); err != nil {
+			return LinkResult{}, err
+		}
+	}
+
+	return LinkResult{
+		From:      from,
+		Relation:  phrase,
+		To:        to,
+		Type:      match.Type.Name,
+		MatchedBy: matchedBy,
+		Warning:   warning,
+		DryRun:    dryRun,
+		URL:       cfg.Server + "/browse/" + from,
+	}, nil
+}
+
+// ---------------------------------------------------------------------------
+// Jira API calls
+// ---------------------------------------------------------------------------
+
+func getLinkTypes(cfg Config) ([]JiraIssueLinkType, error) {
+	return cachedGet(cfg, "linktypes", "", func() ([]JiraIssueLinkType, error) {
+		var resp JiraIssueLinkTypesResponse
+		if err := jiraDo(cfg, http.MethodGet, "/rest/api/3/issueLinkType", nil, nil, &resp); err != nil {
+			return nil, err
+		}
+		return resp.IssueLinkTypes, nil
+	})
+}
+
+func createIssueLink(cfg Config, typeName, inwardKey, outwardKey string) error {
+	body := JiraCreateLinkRequest{
+		Type:         JiraNameField{Name: typeName},
+		InwardIssue:  JiraIssueRef{Key: inwardKey},
+		OutwardIssue: JiraIssueRef{Key: outwardKey},
+	}
+	return jiraDo(cfg, http.MethodPost, "/rest/api/3/issueLink", nil, body, nil)
+}
+
+func deleteIssueLink(cfg Config, linkID string) error {
+	return jiraDo(cfg, http.MethodDelete, "/rest/api/3/issueLink/"+url.PathEscape(linkID), nil, nil, nil)
+}
+
+// ---------------------------------------------------------------------------
+// Link helpers
+// ---------------------------------------------------------------------------
+
+// linkTypeMatch is a link type resolved from a relation phrase. Inward is set
+// when the phrase matched the type's inward description ("is blocked by").
+type linkTypeMatch struct {
+	Type   JiraIssueLinkType
+	Inward bool
+}
+
+// matchLinkType resolves a relation phrase against link type names and their
+// outward/inward descriptions, using the same tiers as matchTransition.
+func matchLinkType(types []JiraIssueLinkType, relation string) (linkTypeMatch, string, string, error) {
+	query := strings.TrimSpace(relation)
+	if query == "" {
+		return linkTypeMatch{}, "", "", errors.New("link type is required (e.g. blocks, relates to)")
+	}
+
+	var phrases []string
+	var matches []linkTypeMatch
+	for _, t := range types {
+		phrases = append(phrases, t.Name, t.Outward, t.Inward)
+		matches = append(matches,
+			linkTypeMatch{Type: t},
+			linkTypeMatch{Type: t},
+			linkTypeMatch{Type: t, Inward: true},
+		)
+	}
+
+	indexes, matchedBy := fuzzyMatchNames(phrases, query)
+	if len(indexes) == 0 {
+		var available []string
+		for _, t := range types {
+			available = append(available, fmt.Sprintf("%s (%s / %s)", t.Name, t.Outward, t.Inward))
+		}
+		return linkTypeMatch{}, "", "", fmt.Errorf("no link type matching %q; available link types: %s", query, strings.Join(available, ", "))
+	}
+
+	// Prefer the shortest phrase, then alphabetical order, for determinism.
+	sort.SliceStable(indexes, func(i, j int) bool {
+		pi, pj := phrases[indexes[i]], phrases[indexes[j]]
+		if len(pi) != len(pj) {
+			return len(pi) < len(pj)
+		}
+		return strings.ToLower(pi) < strings.ToLower(pj)
+	})
+	picked := matches[indexes[0]]
+
+	var names []string
+	seen := map[string]bool{}
+	for _, i := range indexes {
+		m := matches[i]
+		label := m.Type.Outward
+		if m.Inward {
+			label = m.Type.Inward
+		}
+		if seen[m.Type.ID+label] {
+			continue
+		}
+		seen[m.Type.ID+label] = true
+		names = append(names, label)
+	}
+
+	warning := ""
+	if matchedBy != "exact" && len(names) > 1 {
+		selected := picked.Type.Outward
+		if picked.Inward {
+			selected = picked.Type.Inward
+		}
+		warning = fmt.Sprintf("link type %q matched multiple relations (%s); using %q", query, strings.Join(names, ", "), selected)
+	}
+	return picked, matchedBy, warning, nil
+}
+
+// issueLinksToViews describes each link from the viewed issue's side.
+func issueLinksToViews(links []JiraIssueLink) []IssueLinkView {
+	var views []IssueLinkView
+	for _, l := range links {
+		v := IssueLinkView{ID: l.ID, Type: l.Type.Name}
+		var other *JiraLinkedIssue
+		if l.OutwardIssue != nil {
+			v.Direction = "outward"
+			v.Relation = l.Type.Outward
+			other = l.OutwardIssue
+		} else if l.InwardIssue != nil {
+			v.Direction = "inward"
+			v.Relation = l.Type.Inward
+			other = l.InwardIssue
+		} else {
+			continue
+		}
+		v.Key = other.Key
+		v.Status = nameOrEmpty(other.Fields.Status)
+		v.Summary = other.Fields.Summary
+		views = append(views, v)
+	}
+	return views
+}
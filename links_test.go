@@ -0,0 +1,54 @@
+package main
+
+import "testing"
+
+func TestMatchLinkTypeResolvesDirection(t *testing.T) {
+	types := []JiraIssueLinkType{
+		{ID: "1", Name: "Blocks", Outward: "blocks", Inward: "is blocked by"},
+		{ID: "2", Name: "Relates", Outward: "relates to", Inward: "relates to"},
+		{ID: "3", Name: "Duplicate", Outward: "duplicates", Inward: "is duplicated by"},
+	}
+
+	cases := []struct {
+		query     string
+		wantType  string
+		wantIn    bool
+		matchedBy string
+	}{
+		{"blocks", "Blocks", false, "exact"},
+		{"is blocked", "Blocks", true, "prefix"},
+		{"dup", "Duplicate", false, "prefix"},
+		{"relates", "Relates", false, "exact"},
+	}
+	for _, tc := range cases {
+		match, matchedBy, _, err := matchLinkType(types, tc.query)
+		if err != nil {
+			t.Fatalf("matchLinkType(%q) returned error: %v", tc.query, err)
+		}
+		if match.Type.Name != tc.wantType || match.Inward != tc.wantIn || matchedBy != tc.matchedBy {
+			t.Fatalf("matchLinkType(%q) = %s inward=%v by %s; want %s inward=%v by %s",
+				tc.query, match.Type.Name, match.Inward, matchedBy, tc.wantType, tc.wantIn, tc.matchedBy)
+		}
+	}
+
+	if _, _, _, err := matchLinkType(types, "clones"); err == nil {
+		t.Fatal("expected error for unknown link type")
+	}
+}
+
+func TestIssueLinksToViewsUsesViewedIssueSide(t *testing.T) {
+	blocks := JiraIssueLinkType{Name: "Blocks", Outward: "blocks", Inward: "is blocked by"}
+	views := issueLinksToViews([]JiraIssueLink{
+		{ID: "10", Type: blocks, OutwardIssue: &JiraLinkedIssue{Key: "PROJ-2"}},
+		{ID: "11", Type: blocks, InwardIssue: &JiraLinkedIssue{Key: "PROJ-3"}},
+	})
+	if len(views) != 2 {
+		t.Fatalf("expected 2 link views, got %d", len(views))
+	}
+	if views[0].Relation != "blocks" || views[0].Key != "PROJ-2" {
+		t.Fatalf("unexpected outward link view %+v", views[0])
+	}
+	if views[1].Relation != "is blocked by" || views[1].Direction != "inward" {
+		t.Fatalf("unexpected inward link view %+v", views[1])
+	}
+}
@@ -15,7 +15,9 @@ import (
 	"path/filepath"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -23,6 +25,9 @@ var version = "dev"
 
 const defaultHTTPTimeout = 30 * time.Second
 
+// issueSearchFields is the field list requested for issue list views.
+const issueSearchFields = "summary,status,issuetype,priority,assignee,reporter,created,updated,labels,components"
+
 // ---------------------------------------------------------------------------
 // Config types
 // ---------------------------------------------------------------------------
@@ -57,20 +62,29 @@ type JiraIssue struct {
 }
 
 type JiraIssueFields struct {
-	Summary     string          `json:"summary"`
-	Description any             `json:"description"`
-	Status      *JiraNameField  `json:"status"`
-	IssueType   *JiraNameField  `json:"issuetype"`
-	Priority    *JiraNameField  `json:"priority"`
-	Assignee    *JiraUser       `json:"assignee"`
-	Reporter    *JiraUser       `json:"reporter"`
-	Created     string          `json:"created"`
-	Updated     string          `json:"updated"`
-	Labels      []string        `json:"labels"`
-	Components  []JiraNameField `json:"components"`
+	Summary     string            `json:"summary"`
+	Description any               `json:"description"`
+	Status      *JiraNameField    `json:"status"`
+	IssueType   *JiraNameField    `json:"issuetype"`
+	Priority    *JiraNameField    `json:"priority"`
+	Assignee    *JiraUser         `json:"assignee"`
+	Reporter    *JiraUser         `json:"reporter"`
+	Created     string            `json:"created"`
+	Updated     string            `json:"updated"`
+	Labels      []string          `json:"labels"`
+	Components  []JiraNameField   `json:"components"`
+	IssueLinks  []JiraIssueLink   `json:"issuelinks"`
+	Parent      *JiraLinkedIssue  `json:"parent"`
+	Subtasks    []JiraLinkedIssue `json:"subtasks"`
+	Attachments []JiraAttachment  `json:"attachment"`
+	Resolution  *JiraNameField    `json:"resolution,omitempty"`
+	FixVersions []JiraNameField   `json:"fixVersions,omitempty"`
+	// Comment is only requested by watch, which tracks comment counts.
+	Comment *JiraCommentsResponse `json:"comment,omitempty"`
 }
 
 type JiraNameField struct {
+	ID   string `json:"id,omitempty"`
 	Name string `json:"name"`
 }
 
@@ -96,13 +110,16 @@ type JiraTransitionsResponse struct {
 }
 
 type JiraTransition struct {
-	ID   string         `json:"id"`
-	Name string         `json:"name"`
-	To   *JiraNameField `json:"to"`
+	ID     string                   `json:"id"`
+	Name   string                   `json:"name"`
+	To     *JiraNameField           `json:"to"`
+	Fields map[string]JiraFieldMeta `json:"fields,omitempty"`
 }
 
 type JiraTransitionRequest struct {
-	Transition JiraTransitionID `json:"transition"`
+	Transition JiraTransitionID            `json:"transition"`
+	Fields     map[string]any              `json:"fields,omitempty"`
+	Update     map[string][]map[string]any `json:"update,omitempty"`
 }
 
 type JiraTransitionID struct {
@@ -130,7 +147,7 @@ type JiraADFParagraph struct {
 
 type JiraADFText struct {
 	Type string `json:"type"`
-	Text string `json:"text"`
+	Text string `json:"text,omitempty"`
 }
 
 // ---------------------------------------------------------------------------
@@ -138,15 +155,17 @@ type JiraADFText struct {
 // ---------------------------------------------------------------------------
 
 type IssueView struct {
-	Key      string `json:"key"`
-	Summary  string `json:"summary"`
-	Status   string `json:"status"`
-	Type     string `json:"type"`
-	Priority string `json:"priority"`
-	Assignee string `json:"assignee"`
-	Created  string `json:"created"`
-	Updated  string `json:"updated"`
-	URL      string `json:"url"`
+	Key        string   `json:"key"`
+	Summary    string   `json:"summary"`
+	Status     string   `json:"status"`
+	Type       string   `json:"type"`
+	Priority   string   `json:"priority"`
+	Assignee   string   `json:"assignee"`
+	Labels     []string `json:"labels,omitempty"`
+	Components []string `json:"components,omitempty"`
+	Created    string   `json:"created"`
+	Updated    string   `json:"updated"`
+	URL        string   `json:"url"`
 }
 
 type IssueListView struct {
@@ -155,12 +174,28 @@ type IssueListView struct {
 	Total   int         `json:"total"`
 	HasMore bool        `json:"has_more"`
 	Issues  []IssueView `json:"issues"`
+	// Offline results come from the local mirror as of SyncedAt.
+	Offline  bool   `json:"offline,omitempty"`
+	SyncedAt string `json:"synced_at,omitempty"`
 }
 
 type IssueDetailView struct {
 	IssueView
-	Description string        `json:"description"`
-	Comments    []CommentView `json:"comments,omitempty"`
+	Description string           `json:"description"`
+	Parent      *IssueRefView    `json:"parent,omitempty"`
+	Children    []IssueRefView   `json:"children,omitempty"`
+	Links       []IssueLinkView  `json:"links,omitempty"`
+	Attachments []AttachmentView `json:"attachments,omitempty"`
+	Comments    []CommentView    `json:"comments,omitempty"`
+	Offline     bool             `json:"offline,omitempty"`
+	SyncedAt    string           `json:"synced_at,omitempty"`
+}
+
+type IssueRefView struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+	Status  string `json:"status"`
+	Type    string `json:"type"`
 }
 
 type CommentView struct {
@@ -170,23 +205,41 @@ type CommentView struct {
 }
 
 type TransitionResult struct {
-	Key       string `json:"key"`
-	Status    string `json:"status"`
-	MatchedBy string `json:"matched_by,omitempty"`
-	Warning   string `json:"warning,omitempty"`
-	URL       string `json:"url"`
+	Key       string   `json:"key"`
+	Status    string   `json:"status"`
+	MatchedBy string   `json:"matched_by,omitempty"`
+	Warning   string   `json:"warning,omitempty"`
+	Fields    []string `json:"fields,omitempty"`
+	Comment   string   `json:"comment,omitempty"`
+	DryRun    bool     `json:"dry_run,omitempty"`
+	URL       string   `json:"url"`
 }
 
 type AssignResult struct {
 	Key          string `json:"key"`
 	Assignee     string `json:"assignee"`
 	AssigneeName string `json:"assignee_name"`
+	DryRun       bool   `json:"dry_run,omitempty"`
 	URL          string `json:"url"`
 }
 
+type CreateResult struct {
+	Key     string `json:"key,omitempty"`
+	ID      string `json:"id,omitempty"`
+	Project string `json:"project"`
+	Type    string `json:"type"`
+	Summary string `json:"summary"`
+	Parent  string `json:"parent,omitempty"`
+	DryRun  bool   `json:"dry_run,omitempty"`
+	URL     string `json:"url,omitempty"`
+	// Duplicates are candidates found by --check-duplicates in warn mode.
+	Duplicates []SimilarIssue `json:"duplicates,omitempty"`
+}
+
 type CommentResult struct {
 	Key     string `json:"key"`
 	Comment string `json:"comment"`
+	DryRun  bool   `json:"dry_run,omitempty"`
 	URL     string `json:"url"`
 }
 
@@ -196,6 +249,10 @@ type CommentResult struct {
 
 func main() {
 	if err := run(); err != nil {
+		var reported reportedError
+		if errors.As(err, &reported) {
+			os.Exit(1)
+		}
 		if shouldPrintJSONError() {
 			_ = printJSONError(err)
 			os.Exit(1)
@@ -206,6 +263,7 @@ func main() {
 }
 
 func run() error {
+	os.Args, noCache = stripNoCacheFlag(os.Args)
 	if len(os.Args) < 2 {
 		printRootHelp()
 		return nil
@@ -216,6 +274,42 @@ func run() error {
 		return runAuth(os.Args[2:])
 	case "issues":
 		return runIssues(os.Args[2:])
+	case "boards":
+		return runBoards(os.Args[2:])
+	case "sprints":
+		return runSprints(os.Args[2:])
+	case "link-types":
+		return runLinkTypes(os.Args[2:])
+	case "graph":
+		return runGraph(os.Args[2:])
+	case "worklog":
+		return runWorklog(os.Args[2:])
+	case "timer":
+		return runTimer(os.Args[2:])
+	case "attachments":
+		return runAttachments(os.Args[2:])
+	case "bulk":
+		return runBulk(os.Args[2:])
+	case "batch":
+		return runBatch(os.Args[2:])
+	case "git":
+		return runGit(os.Args[2:])
+	case "release-notes":
+		return runReleaseNotes(os.Args[2:])
+	case "versions":
+		return runVersions(os.Args[2:])
+	case "projects":
+		return runProjects(os.Args[2:])
+	case "meta":
+		return runMeta(os.Args[2:])
+	case "cache":
+		return runCache(os.Args[2:])
+	case "sync":
+		return runSync(os.Args[2:])
+	case "find":
+		return runFind(os.Args[2:])
+	case "watch":
+		return runWatch(os.Args[2:])
 	case "version", "--version", "-v":
 		fmt.Printf("jiractl %s\n", version)
 		return nil
@@ -241,14 +335,72 @@ func printRootHelp() {
 	fmt.Println("  auth logout   Remove stored credentials")
 	fmt.Println("  issues mine       List issues assigned to you")
 	fmt.Println("  issues view       View a single issue by key")
+	fmt.Println("  issues current    View the issue named in the current git branch")
 	fmt.Println("  issues search     Search issues with JQL")
 	fmt.Println("  issues transition Change issue status")
 	fmt.Println("  issues assign     Reassign an issue")
 	fmt.Println("  issues comment    Add a comment to an issue")
+	fmt.Println("  issues create     Create an issue, subtask or epic child")
+	fmt.Println("  issues similar    Find likely duplicates of an issue before filing it")
+	fmt.Println("  issues children   Show subtasks and child issues as a tree")
+	fmt.Println("  issues set-parent Move an issue under a parent or epic")
+	fmt.Println("  issues edit       Edit summary, priority or fix versions")
+	fmt.Println("  issues rank       Rank issues before or after another issue")
+	fmt.Println("  issues link       Link two issues (e.g. PROJ-1 blocks PROJ-2)")
+	fmt.Println("  issues unlink     Delete an issue link")
+	fmt.Println("  issues label      Add or remove labels on issues")
+	fmt.Println("  issues component  Add or remove components on issues")
+	fmt.Println("  issues watch      Watch an issue or add watchers")
+	fmt.Println("  issues unwatch    Stop watching or remove watchers")
+	fmt.Println("  issues watchers   List watchers of an issue")
+	fmt.Println("  issues vote       Vote for an issue")
+	fmt.Println("  issues unvote     Remove your vote")
+	fmt.Println("  boards list       List agile boards")
+	fmt.Println("  sprints list      List sprints on a board")
+	fmt.Println("  sprints view      View a single sprint")
+	fmt.Println("  sprints issues    List issues in a sprint")
+	fmt.Println("  sprints add       Move issues into a sprint")
+	fmt.Println("  sprints remove    Move issues back to the backlog")
+	fmt.Println("  sprints report    Sprint scope, completion and burndown")
+	fmt.Println("  link-types list   List issue link types")
+	fmt.Println("  graph             Dependency graph for issues matching JQL")
+	fmt.Println("  worklog add       Log time on an issue")
+	fmt.Println("  worklog list      List worklogs on an issue")
+	fmt.Println("  worklog delete    Delete a worklog")
+	fmt.Println("  worklog report    Hours per issue and day")
+	fmt.Println("  timer start       Start a local timer on an issue")
+	fmt.Println("  timer status      Show the running timer")
+	fmt.Println("  timer stop        Stop the timer and log the time")
+	fmt.Println("  timer discard     Drop the timer without logging")
+	fmt.Println("  attachments list      List attachments on an issue")
+	fmt.Println("  attachments download  Download attachments")
+	fmt.Println("  attachments add       Upload files to an issue")
+	fmt.Println("  bulk transition   Transition every issue matching JQL")
+	fmt.Println("  bulk assign       Assign every issue matching JQL")
+	fmt.Println("  bulk label        Add/remove labels on issues matching JQL")
+	fmt.Println("  bulk comment      Comment on every issue matching JQL")
+	fmt.Println("  batch run         Run operations from an NDJSON file or stdin")
+	fmt.Println("  git branch        Create a git branch named after an issue")
+	fmt.Println("  git issues        List issues referenced by commits in a range")
+	fmt.Println("  release-notes     Release notes from a fix version or git range")
+	fmt.Println("  versions list     List a project's versions")
+	fmt.Println("  versions create   Create a version")
+	fmt.Println("  versions release  Release a version, moving unresolved issues")
+	fmt.Println("  projects list     List projects")
+	fmt.Println("  projects view     Show a project's lead, components, types and versions")
+	fmt.Println("  meta statuses     List statuses (per project with --project)")
+	fmt.Println("  meta priorities   List priorities")
+	fmt.Println("  meta createmeta   List create fields and allowed values for an issue type")
+	fmt.Println("  cache stats       Show cached metadata")
+	fmt.Println("  cache clear       Drop cached metadata")
+	fmt.Println("  sync              Mirror issues matching JQL for --offline use")
+	fmt.Println("  find              Ranked full-text search over the mirror")
+	fmt.Println("  watch             Poll a JQL query and print change events")
 	fmt.Println("  version       Print version")
 	fmt.Println("  help          Show this help")
 	fmt.Println()
 	fmt.Println("Use --json on data commands for agent-friendly output.")
+	fmt.Println("Use --no-cache on any command to bypass the metadata cache.")
 }
 
 func printAuthHelp() {
@@ -260,12 +412,29 @@ func printAuthHelp() {
 
 func printIssuesHelp() {
 	fmt.Println("jiractl issues commands:")
-	fmt.Println("  issues mine       [--limit N] [--status STATUS] [--json]")
-	fmt.Println("  issues view       ISSUE-KEY [--comment-limit N] [--json]")
-	fmt.Println("  issues search     --jql \"...\" [--limit N] [--json]")
-	fmt.Println("  issues transition ISSUE-KEY --status \"STATUS\" [--json]")
+	fmt.Println("  issues mine       [--limit N] [--status STATUS] [--sprint current|ID] [--watching] [--offline] [--json]")
+	fmt.Println("  issues view       ISSUE-KEY [--comment-limit N] [--offline] [--json]")
+	fmt.Println("  issues current    [--pattern REGEX] [--comment-limit N] [--offline] [--json]")
+	fmt.Println("  issues search     --jql \"...\" [--limit N] [--offline] [--json]")
+	fmt.Println("  issues transition ISSUE-KEY --status \"STATUS\" [--resolution NAME] [--field NAME=VALUE]... [--comment TEXT] [--path auto] [--dry-run] [--json]")
+	fmt.Println("                    (--path auto --dry-run plans multi-step paths only with admin rights to read the workflow)")
 	fmt.Println("  issues assign     ISSUE-KEY [--email EMAIL] [--json]")
 	fmt.Println("  issues comment    ISSUE-KEY --body \"TEXT\" [--json]")
+	fmt.Println("  issues rank       ISSUE-KEY... --before KEY | --after KEY [--dry-run] [--json]")
+	fmt.Println("  issues create     --project KEY --summary \"TEXT\" [--type TYPE] [--parent KEY] [--description TEXT] [--priority NAME] [--label L] [--check-duplicates [--duplicate-threshold 0.6] [--on-duplicate refuse|warn]] [--dry-run] [--json]")
+	fmt.Println("  issues similar    --summary \"TEXT\" [--description-file FILE] [--project KEY]... [--limit N] [--min-score S] [--json]")
+	fmt.Println("  issues children   ISSUE-KEY [--recursive] [--json]")
+	fmt.Println("  issues set-parent ISSUE-KEY --parent KEY [--dry-run] [--json]")
+	fmt.Println("  issues edit       ISSUE-KEY [--summary TEXT] [--priority NAME] [--fix-version V | --add-fix-version V --remove-fix-version V] [--dry-run] [--json]")
+	fmt.Println("  issues link       ISSUE-KEY LINK-TYPE ISSUE-KEY [--dry-run] [--json]")
+	fmt.Println("  issues unlink     LINK-ID [--json]")
+	fmt.Println("  issues label      ISSUE-KEY... [--add L] [--remove L] [--dry-run] [--json]")
+	fmt.Println("  issues component  ISSUE-KEY... [--add C] [--remove C] [--dry-run] [--json]")
+	fmt.Println("  issues watch      ISSUE-KEY [--user EMAIL]... [--dry-run] [--json]")
+	fmt.Println("  issues unwatch    ISSUE-KEY [--user EMAIL]... [--dry-run] [--json]")
+	fmt.Println("  issues watchers   ISSUE-KEY [--json]")
+	fmt.Println("  issues vote       ISSUE-KEY [--json]")
+	fmt.Println("  issues unvote     ISSUE-KEY [--json]")
 }
 
 // ---------------------------------------------------------------------------
@@ -427,6 +596,8 @@ func runIssues(args []string) error {
 		return runIssuesMine(args[1:])
 	case "view":
 		return runIssuesView(args[1:])
+	case "current":
+		return runIssuesCurrent(args[1:])
 	case "search":
 		return runIssuesSearch(args[1:])
 	case "transition":
@@ -435,6 +606,36 @@ func runIssues(args []string) error {
 		return runIssuesAssign(args[1:])
 	case "comment":
 		return runIssuesComment(args[1:])
+	case "rank":
+		return runIssuesRank(args[1:])
+	case "link":
+		return runIssuesLink(args[1:])
+	case "create":
+		return runIssuesCreate(args[1:])
+	case "similar":
+		return runIssuesSimilar(args[1:])
+	case "children":
+		return runIssuesChildren(args[1:])
+	case "set-parent":
+		return runIssuesSetParent(args[1:])
+	case "edit":
+		return runIssuesEdit(args[1:])
+	case "unlink":
+		return runIssuesUnlink(args[1:])
+	case "label":
+		return runIssuesValues(args[1:], "labels")
+	case "component":
+		return runIssuesValues(args[1:], "components")
+	case "watch":
+		return runIssuesWatch(args[1:], true)
+	case "unwatch":
+		return runIssuesWatch(args[1:], false)
+	case "watchers":
+		return runIssuesWatchers(args[1:])
+	case "vote":
+		return runIssuesVote(args[1:], true)
+	case "unvote":
+		return runIssuesVote(args[1:], false)
 	case "help", "--help", "-h":
 		printIssuesHelp()
 		return nil
@@ -448,6 +649,9 @@ func runIssuesMine(args []string) error {
 	fs := flag.NewFlagSet("issues mine", flag.ContinueOnError)
 	limit := fs.Int("limit", 50, "max issues to return")
 	status := fs.String("status", "", "filter by status (e.g. \"In Progress\")")
+	sprint := fs.String("sprint", "", "filter by sprint: current, a sprint ID or a sprint name")
+	watching := fs.Bool("watching", false, "list issues you watch instead of issues assigned to you")
+	offline := fs.Bool("offline", false, "answer from the local mirror (see jiractl sync)")
 	jsonOut := fs.Bool("json", false, "print JSON")
 	if err := fs.Parse(args); err != nil {
 		return err
@@ -462,12 +666,21 @@ func runIssuesMine(args []string) error {
 		return err
 	}
 
-	jql := "assignee = currentUser() ORDER BY updated DESC"
+	clauses := []string{"assignee = currentUser()"}
+	noun := "Assigned"
+	if *watching {
+		clauses[0] = "watcher = currentUser()"
+		noun = "Watched"
+	}
 	if *status != "" {
-		jql = fmt.Sprintf("assignee = currentUser() AND status = %q ORDER BY updated DESC", *status)
+		clauses = append(clauses, fmt.Sprintf("status = %q", *status))
 	}
+	if *sprint != "" {
+		clauses = append(clauses, sprintClause(*sprint))
+	}
+	jql := strings.Join(clauses, " AND ") + " ORDER BY updated DESC"
 
-	searchResult, err := searchIssues(cfg, jql, *limit)
+	searchResult, syncedAt, err := searchIssuesOrMirror(cfg, jql, *limit, offlineRequested(*offline))
 	if err != nil {
 		return err
 	}
@@ -480,20 +693,31 @@ func runIssuesMine(args []string) error {
 		HasMore: searchResult.HasMore,
 		Issues:  views,
 	}
+	if !syncedAt.IsZero() {
+		out.Offline = true
+		out.SyncedAt = syncedAt.Format(time.RFC3339)
+	}
 
 	if *jsonOut {
 		return printJSON(out)
 	}
+	if out.Offline {
+		printOfflineNotice(syncedAt)
+	}
 
 	if len(views) == 0 {
-		fmt.Println("No issues assigned to you.")
+		if *watching {
+			fmt.Println("No issues watched by you.")
+		} else {
+			fmt.Println("No issues assigned to you.")
+		}
 		return nil
 	}
 
 	if out.Total > len(views) || out.HasMore {
-		fmt.Printf("Assigned issues (%d of %d):\n", len(views), out.Total)
+		fmt.Printf("%s issues (%d of %d):\n", noun, len(views), out.Total)
 	} else {
-		fmt.Printf("Assigned issues (%d):\n", len(views))
+		fmt.Printf("%s issues (%d):\n", noun, len(views))
 	}
 	for _, v := range views {
 		fmt.Printf("- %-12s  [%s]  %s\n", v.Key, v.Status, v.Summary)
@@ -504,40 +728,75 @@ func runIssuesMine(args []string) error {
 func runIssuesView(args []string) error {
 	fs := flag.NewFlagSet("issues view", flag.ContinueOnError)
 	commentLimit := fs.Int("comment-limit", 20, "max comments to return")
+	offline := fs.Bool("offline", false, "answer from the local mirror (see jiractl sync)")
 	jsonOut := fs.Bool("json", false, "print JSON")
-	if err := fs.Parse(args); err != nil {
+	remaining, err := parseFlags(fs, args)
+	if err != nil {
 		return err
 	}
 	if *commentLimit <= 0 {
 		return errors.New("--comment-limit must be greater than 0")
 	}
 
-	remaining := fs.Args()
 	if len(remaining) == 0 {
 		return errors.New("issue key is required (e.g. jiractl issues view PROJ-123)")
 	}
 	issueKey := strings.ToUpper(remaining[0])
 
+	return showIssue(issueKey, *commentLimit, offlineRequested(*offline), *jsonOut)
+}
+
+// showIssue prints an issue with its parent, children, links, attachments and
+// comments. It backs "issues view" and "issues current".
+func showIssue(issueKey string, commentLimit int, offline, jsonOut bool) error {
 	cfg, err := loadAuthConfig()
 	if err != nil {
 		return err
 	}
 
-	issue, err := getIssue(cfg, issueKey)
-	if err != nil {
-		return err
-	}
+	var view IssueDetailView
+	if offline {
+		var syncedAt time.Time
+		view, syncedAt, err = offlineIssueDetail(cfg, issueKey, commentLimit)
+		if err != nil {
+			return err
+		}
+		view.Offline = true
+		view.SyncedAt = syncedAt.Format(time.RFC3339)
+	} else {
+		issue, err := getIssue(cfg, issueKey)
+		if err != nil {
+			return err
+		}
 
-	comments, err := getComments(cfg, issueKey, *commentLimit)
-	if err != nil {
-		return err
-	}
+		comments, err := getComments(cfg, issueKey, commentLimit)
+		if err != nil {
+			return err
+		}
 
-	view := issueToDetailView(issue, cfg.Server, comments)
+		view = issueToDetailView(issue, cfg.Server, comments)
 
-	if *jsonOut {
+		// Subtasks come with the issue; epic children only via search. The
+		// issue itself is what was asked for, so a failed search only warns.
+		children, err := searchIssues(cfg, fmt.Sprintf("parent = %s ORDER BY key ASC", issueKey), 100)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: could not look up child issues: %v\n", err)
+		}
+		for _, child := range children.Issues {
+			if !hasIssueRef(view.Children, child.Key) {
+				view.Children = append(view.Children, issueToRef(child))
+			}
+		}
+	}
+
+	if jsonOut {
 		return printJSON(view)
 	}
+	if view.Offline {
+		if t, err := time.Parse(time.RFC3339, view.SyncedAt); err == nil {
+			printOfflineNotice(t)
+		}
+	}
 
 	fmt.Printf("Key:         %s\n", view.Key)
 	fmt.Printf("Summary:     %s\n", view.Summary)
@@ -547,10 +806,35 @@ func runIssuesView(args []string) error {
 	fmt.Printf("Assignee:    %s\n", view.Assignee)
 	fmt.Printf("Created:     %s\n", view.Created)
 	fmt.Printf("Updated:     %s\n", view.Updated)
+	if len(view.Labels) > 0 {
+		fmt.Printf("Labels:      %s\n", strings.Join(view.Labels, ", "))
+	}
+	if len(view.Components) > 0 {
+		fmt.Printf("Components:  %s\n", strings.Join(view.Components, ", "))
+	}
+	if view.Parent != nil {
+		fmt.Printf("Parent:      %s [%s] %s\n", view.Parent.Key, view.Parent.Status, view.Parent.Summary)
+	}
 	fmt.Printf("URL:         %s\n", view.URL)
 	if view.Description != "" {
 		fmt.Printf("\nDescription:\n%s\n", view.Description)
 	}
+	if len(view.Children) > 0 {
+		fmt.Printf("\nChildren (%d):\n", len(view.Children))
+		for _, c := range view.Children {
+			fmt.Printf("  %-12s [%s]  %s\n", c.Key, c.Status, c.Summary)
+		}
+	}
+	if len(view.Links) > 0 {
+		fmt.Printf("\nLinks (%d):\n", len(view.Links))
+		for _, l := range view.Links {
+			fmt.Printf("  %-16s %-12s [%s]  %s  (link %s)\n", l.Relation, l.Key, l.Status, l.Summary, l.ID)
+		}
+	}
+	if len(view.Attachments) > 0 {
+		fmt.Printf("\nAttachments (%d):\n", len(view.Attachments))
+		printAttachmentViews(view.Attachments)
+	}
 	if len(view.Comments) > 0 {
 		fmt.Printf("\nComments (%d):\n", len(view.Comments))
 		for _, c := range view.Comments {
@@ -564,6 +848,7 @@ func runIssuesSearch(args []string) error {
 	fs := flag.NewFlagSet("issues search", flag.ContinueOnError)
 	jql := fs.String("jql", "", "JQL query string")
 	limit := fs.Int("limit", 50, "max issues to return")
+	offline := fs.Bool("offline", false, "answer from the local mirror using a JQL subset (see jiractl sync)")
 	jsonOut := fs.Bool("json", false, "print JSON")
 	if err := fs.Parse(args); err != nil {
 		return err
@@ -581,7 +866,7 @@ func runIssuesSearch(args []string) error {
 		return err
 	}
 
-	searchResult, err := searchIssues(cfg, *jql, *limit)
+	searchResult, syncedAt, err := searchIssuesOrMirror(cfg, *jql, *limit, offlineRequested(*offline))
 	if err != nil {
 		return err
 	}
@@ -594,10 +879,17 @@ func runIssuesSearch(args []string) error {
 		HasMore: searchResult.HasMore,
 		Issues:  views,
 	}
+	if !syncedAt.IsZero() {
+		out.Offline = true
+		out.SyncedAt = syncedAt.Format(time.RFC3339)
+	}
 
 	if *jsonOut {
 		return printJSON(out)
 	}
+	if out.Offline {
+		printOfflineNotice(syncedAt)
+	}
 
 	if len(views) == 0 {
 		fmt.Println("No issues found.")
@@ -618,12 +910,18 @@ func runIssuesSearch(args []string) error {
 func runIssuesTransition(args []string) error {
 	fs := flag.NewFlagSet("issues transition", flag.ContinueOnError)
 	status := fs.String("status", "", "target status name (required)")
+	resolution := fs.String("resolution", "", "resolution to set, e.g. Fixed")
+	var fieldArgs rawList
+	fs.Var(&fieldArgs, "field", "screen field as NAME=VALUE (repeatable; NAME is a field ID or name)")
+	comment := fs.String("comment", "", "comment to add with the transition")
+	path := fs.String("path", "", "\"auto\" to pass through intermediate statuses when needed")
+	dryRun := fs.Bool("dry-run", false, "resolve the transition and fields without applying them")
 	jsonOut := fs.Bool("json", false, "print JSON")
-	if err := fs.Parse(args); err != nil {
+	remaining, err := parseFlags(fs, args)
+	if err != nil {
 		return err
 	}
 
-	remaining := fs.Args()
 	if len(remaining) == 0 {
 		return errors.New("issue key is required (e.g. jiractl issues transition PROJ-123 --status \"In Progress\")")
 	}
@@ -638,36 +936,41 @@ func runIssuesTransition(args []string) error {
 		return err
 	}
 
-	transitions, err := getTransitions(cfg, issueKey)
+	fieldValues, err := parseFieldAssignments(fieldArgs)
 	if err != nil {
 		return err
 	}
+	opts := TransitionOptions{Resolution: *resolution, Fields: fieldValues, Comment: *comment}
 
-	matched, matchedBy, warning, err := matchTransition(transitions, *status)
-	if err != nil {
-		return err
+	switch *path {
+	case "", "direct":
+	case "auto":
+		return runPathTransition(cfg, issueKey, *status, opts, *dryRun, *jsonOut)
+	default:
+		return fmt.Errorf("invalid --path %q (expected auto)", *path)
 	}
 
-	if err := doTransition(cfg, issueKey, matched.ID); err != nil {
+	result, err := transitionIssue(cfg, issueKey, *status, opts, *dryRun)
+	if err != nil {
 		return err
 	}
 
-	result := TransitionResult{
-		Key:       issueKey,
-		Status:    matched.Name,
-		MatchedBy: matchedBy,
-		Warning:   warning,
-		URL:       cfg.Server + "/browse/" + issueKey,
-	}
-
 	if *jsonOut {
 		return printJSON(result)
 	}
 
-	if warning != "" {
-		fmt.Fprintln(os.Stderr, "warning:", warning)
+	if result.Warning != "" {
+		fmt.Fprintln(os.Stderr, "warning:", result.Warning)
+	}
+	if *dryRun {
+		fmt.Printf("Would transition %s via %s", result.Key, result.Status)
+	} else {
+		fmt.Printf("%s transitioned to %s", result.Key, result.Status)
+	}
+	if len(result.Fields) > 0 {
+		fmt.Printf(" (set %s)", strings.Join(result.Fields, ", "))
 	}
-	fmt.Printf("%s transitioned to %s\n", result.Key, result.Status)
+	fmt.Println()
 	return nil
 }
 
@@ -675,11 +978,11 @@ func runIssuesAssign(args []string) error {
 	fs := flag.NewFlagSet("issues assign", flag.ContinueOnError)
 	email := fs.String("email", "", "assignee email (defaults to reporter)")
 	jsonOut := fs.Bool("json", false, "print JSON")
-	if err := fs.Parse(args); err != nil {
+	remaining, err := parseFlags(fs, args)
+	if err != nil {
 		return err
 	}
 
-	remaining := fs.Args()
 	if len(remaining) == 0 {
 		return errors.New("issue key is required (e.g. jiractl issues assign PROJ-123)")
 	}
@@ -690,50 +993,26 @@ func runIssuesAssign(args []string) error {
 		return err
 	}
 
-	var accountID string
-	var displayName string
-	var assigneeEmail string
-
-	if *email == "" {
-		// Default to reporter
-		issue, err := getIssue(cfg, issueKey)
+	var user *JiraUser
+	if *email != "" {
+		u, err := resolveUser(cfg, *email)
 		if err != nil {
 			return err
 		}
-		if issue.Fields.Reporter == nil || issue.Fields.Reporter.AccountID == "" {
-			return errors.New("issue has no reporter; use --email to specify an assignee")
-		}
-		accountID = issue.Fields.Reporter.AccountID
-		displayName = issue.Fields.Reporter.DisplayName
-		assigneeEmail = issue.Fields.Reporter.EmailAddress
-	} else {
-		users, err := searchUser(cfg, *email)
-		if err != nil {
-			return err
-		}
-		if len(users) == 0 {
-			return fmt.Errorf("no user found for %q", *email)
-		}
-		accountID = users[0].AccountID
-		displayName = users[0].DisplayName
-		assigneeEmail = users[0].EmailAddress
+		user = &u
 	}
 
-	if err := assignIssue(cfg, issueKey, accountID); err != nil {
+	result, err := assignIssueTo(cfg, issueKey, user, false)
+	if err != nil {
 		return err
 	}
 
-	result := AssignResult{
-		Key:          issueKey,
-		Assignee:     assigneeEmail,
-		AssigneeName: displayName,
-		URL:          cfg.Server + "/browse/" + issueKey,
-	}
-
 	if *jsonOut {
 		return printJSON(result)
 	}
 
+	displayName, assigneeEmail := result.AssigneeName, result.Assignee
+
 	if displayName != "" && assigneeEmail != "" {
 		fmt.Printf("%s assigned to %s (%s)\n", result.Key, displayName, assigneeEmail)
 	} else if displayName != "" {
@@ -748,11 +1027,11 @@ func runIssuesComment(args []string) error {
 	fs := flag.NewFlagSet("issues comment", flag.ContinueOnError)
 	body := fs.String("body", "", "comment text (required)")
 	jsonOut := fs.Bool("json", false, "print JSON")
-	if err := fs.Parse(args); err != nil {
+	remaining, err := parseFlags(fs, args)
+	if err != nil {
 		return err
 	}
 
-	remaining := fs.Args()
 	if len(remaining) == 0 {
 		return errors.New("issue key is required (e.g. jiractl issues comment PROJ-123 --body \"text\")")
 	}
@@ -767,24 +1046,165 @@ func runIssuesComment(args []string) error {
 		return err
 	}
 
-	if err := addComment(cfg, issueKey, *body); err != nil {
+	result, err := commentOnIssue(cfg, issueKey, *body, false)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		return printJSON(result)
+	}
+
+	fmt.Printf("Comment added to %s\n", result.Key)
+	return nil
+}
+
+func runIssuesCreate(args []string) error {
+	fs := flag.NewFlagSet("issues create", flag.ContinueOnError)
+	project := fs.String("project", "", "project key (defaults to the parent's project)")
+	issueType := fs.String("type", "", "issue type name (default: Task, or the subtask type under a non-epic parent)")
+	summary := fs.String("summary", "", "issue summary (required)")
+	description := fs.String("description", "", "issue description")
+	parent := fs.String("parent", "", "parent issue or epic key")
+	priority := fs.String("priority", "", "priority name")
+	var labels stringList
+	fs.Var(&labels, "label", "label to add (repeatable or comma-separated)")
+	checkDups := fs.Bool("check-duplicates", false, "look for similar issues in the project before creating")
+	threshold := fs.Float64("duplicate-threshold", defaultDuplicateThreshold, "similarity score (0-1) at which a candidate counts as a duplicate")
+	onDuplicate := fs.String("on-duplicate", "refuse", "what to do when duplicates are found: refuse or warn")
+	dryRun := fs.Bool("dry-run", false, "show the issue that would be created")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if _, err := parseFlags(fs, args); err != nil {
 		return err
 	}
+	if *onDuplicate != "refuse" && *onDuplicate != "warn" {
+		return fmt.Errorf("--on-duplicate must be refuse or warn, got %q", *onDuplicate)
+	}
+	if *threshold <= 0 || *threshold > 1 {
+		return errors.New("--duplicate-threshold must be greater than 0 and at most 1")
+	}
 
-	result := CommentResult{
-		Key:     issueKey,
-		Comment: *body,
-		URL:     cfg.Server + "/browse/" + issueKey,
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	in := IssueCreateInput{
+		Project:     *project,
+		Type:        *issueType,
+		Summary:     *summary,
+		Description: *description,
+		Parent:      *parent,
+		Priority:    *priority,
+		Labels:      labels,
+	}
+	var duplicates []SimilarIssue
+	if *checkDups && strings.TrimSpace(in.Summary) != "" {
+		if duplicates, err = checkDuplicates(cfg, in, *threshold, *onDuplicate); err != nil {
+			return err
+		}
+	}
+
+	result, err := createIssueFrom(cfg, in, *dryRun)
+	if err != nil {
+		return err
 	}
+	result.Duplicates = duplicates
 
 	if *jsonOut {
 		return printJSON(result)
 	}
 
-	fmt.Printf("Comment added to %s\n", result.Key)
+	for _, d := range duplicates {
+		fmt.Fprintf(os.Stderr, "warning: possible duplicate %s (%.2f): %s\n", d.Key, d.Score, d.Summary)
+	}
+
+	if *dryRun {
+		fmt.Printf("Would create %s in %s: %s\n", result.Type, result.Project, result.Summary)
+		return nil
+	}
+	if result.Parent != "" {
+		fmt.Printf("Created %s (%s under %s): %s\n", result.Key, result.Type, result.Parent, result.URL)
+	} else {
+		fmt.Printf("Created %s (%s): %s\n", result.Key, result.Type, result.URL)
+	}
 	return nil
 }
 
+// IssueCreateInput describes an issue to create. Project defaults to the
+// parent's project and Type to defaultIssueType.
+type IssueCreateInput struct {
+	Project     string   `json:"project"`
+	Type        string   `json:"type"`
+	Summary     string   `json:"summary"`
+	Description string   `json:"description"`
+	Parent      string   `json:"parent"`
+	Priority    string   `json:"priority"`
+	Labels      []string `json:"labels"`
+}
+
+func createIssueFrom(cfg Config, in IssueCreateInput, dryRun bool) (CreateResult, error) {
+	summary := strings.TrimSpace(in.Summary)
+	if summary == "" {
+		return CreateResult{}, errors.New("--summary is required")
+	}
+	parentKey := strings.ToUpper(strings.TrimSpace(in.Parent))
+	projectKey := strings.ToUpper(strings.TrimSpace(in.Project))
+	if projectKey == "" && parentKey != "" {
+		projectKey, _ = splitIssueKey(parentKey)
+	}
+	if projectKey == "" {
+		return CreateResult{}, errors.New("--project is required (or pass --parent)")
+	}
+
+	typeName := strings.TrimSpace(in.Type)
+	if typeName == "" {
+		var err error
+		typeName, err = defaultIssueType(cfg, projectKey, parentKey)
+		if err != nil {
+			return CreateResult{}, err
+		}
+	}
+
+	fields := map[string]any{
+		"project":   map[string]string{"key": projectKey},
+		"issuetype": map[string]string{"name": typeName},
+		"summary":   summary,
+	}
+	if in.Description != "" {
+		fields["description"] = textToADF(in.Description)
+	}
+	if parentKey != "" {
+		fields["parent"] = map[string]string{"key": parentKey}
+	}
+	if in.Priority != "" {
+		fields["priority"] = map[string]string{"name": in.Priority}
+	}
+	if len(in.Labels) > 0 {
+		fields["labels"] = in.Labels
+	}
+
+	result := CreateResult{
+		Project: projectKey,
+		Type:    typeName,
+		Summary: summary,
+		Parent:  parentKey,
+		DryRun:  dryRun,
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	created, err := createIssue(cfg, fields)
+	if err != nil {
+		return CreateResult{}, err
+	}
+	result.Key = created.Key
+	result.ID = created.ID
+	result.URL = cfg.Server + "/browse/" + created.Key
+	return result, nil
+}
+
 // ---------------------------------------------------------------------------
 // Jira API calls
 // ---------------------------------------------------------------------------
@@ -796,6 +1216,11 @@ type SearchIssuesResult struct {
 }
 
 func searchIssues(cfg Config, jql string, limit int) (SearchIssuesResult, error) {
+	return searchIssuesWithFields(cfg, jql, limit, issueSearchFields)
+}
+
+// searchIssuesWithFields is searchIssues with an explicit field list.
+func searchIssuesWithFields(cfg Config, jql string, limit int, fields string) (SearchIssuesResult, error) {
 	result := SearchIssuesResult{}
 	var all []JiraIssue
 	nextPageToken := ""
@@ -812,7 +1237,7 @@ func searchIssues(cfg Config, jql string, limit int) (SearchIssuesResult, error)
 		q := u.Query()
 		q.Set("jql", jql)
 		q.Set("maxResults", fmt.Sprintf("%d", maxResults))
-		q.Set("fields", "summary,status,issuetype,priority,assignee,reporter,created,updated,labels,components")
+		q.Set("fields", fields)
 		if nextPageToken != "" {
 			q.Set("nextPageToken", nextPageToken)
 		}
@@ -853,7 +1278,7 @@ func searchIssues(cfg Config, jql string, limit int) (SearchIssuesResult, error)
 
 func getIssue(cfg Config, issueKey string) (JiraIssue, error) {
 	u := cfg.Server + "/rest/api/3/issue/" + url.PathEscape(issueKey) +
-		"?fields=summary,description,status,issuetype,priority,assignee,reporter,created,updated,labels,components"
+		"?fields=summary,description,status,issuetype,priority,assignee,reporter,created,updated,labels,components,issuelinks,parent,subtasks,attachment"
 
 	client := buildHTTPClient(cfg.Server, cfg.Email, cfg.APIToken)
 	req, err := http.NewRequest(http.MethodGet, u, nil)
@@ -904,7 +1329,7 @@ func getComments(cfg Config, issueKey string, limit int) ([]JiraComment, error)
 }
 
 func getTransitions(cfg Config, issueKey string) ([]JiraTransition, error) {
-	u := cfg.Server + "/rest/api/3/issue/" + url.PathEscape(issueKey) + "/transitions"
+	u := cfg.Server + "/rest/api/3/issue/" + url.PathEscape(issueKey) + "/transitions?expand=transitions.fields"
 
 	client := buildHTTPClient(cfg.Server, cfg.Email, cfg.APIToken)
 	req, err := http.NewRequest(http.MethodGet, u, nil)
@@ -925,10 +1350,12 @@ func getTransitions(cfg Config, issueKey string) ([]JiraTransition, error) {
 	return result.Transitions, nil
 }
 
-func doTransition(cfg Config, issueKey, transitionID string) error {
+// doTransition performs a transition, setting screen fields and applying
+// update operations (such as adding a comment) in the same request.
+func doTransition(cfg Config, issueKey, transitionID string, fields map[string]any, update map[string][]map[string]any) error {
 	u := cfg.Server + "/rest/api/3/issue/" + url.PathEscape(issueKey) + "/transitions"
 
-	body := JiraTransitionRequest{Transition: JiraTransitionID{ID: transitionID}}
+	body := JiraTransitionRequest{Transition: JiraTransitionID{ID: transitionID}, Fields: fields, Update: update}
 	b, err := json.Marshal(body)
 	if err != nil {
 		return err
@@ -972,7 +1399,15 @@ func doTransition(cfg Config, issueKey, transitionID string) error {
 	return nil
 }
 
+// searchUser looks users up by email or name. Results are cached, since agent
+// loops resolve the same few people over and over.
 func searchUser(cfg Config, query string) ([]JiraUser, error) {
+	return cachedGet(cfg, "users", "search:"+strings.ToLower(strings.TrimSpace(query)), func() ([]JiraUser, error) {
+		return fetchUsers(cfg, query)
+	})
+}
+
+func fetchUsers(cfg Config, query string) ([]JiraUser, error) {
 	u, err := url.Parse(cfg.Server + "/rest/api/3/user/search")
 	if err != nil {
 		return nil, err
@@ -1000,6 +1435,30 @@ func searchUser(cfg Config, query string) ([]JiraUser, error) {
 	return users, nil
 }
 
+// resolveUser finds a single user by email or name; "me" is the
+// authenticated user.
+func resolveUser(cfg Config, query string) (JiraUser, error) {
+	if strings.EqualFold(strings.TrimSpace(query), "me") {
+		return getMyself(cfg)
+	}
+	users, err := searchUser(cfg, query)
+	if err != nil {
+		return JiraUser{}, err
+	}
+	if len(users) == 0 {
+		return JiraUser{}, fmt.Errorf("no user found for %q", query)
+	}
+	return users[0], nil
+}
+
+func getMyself(cfg Config) (JiraUser, error) {
+	return cachedGet(cfg, "users", "myself", func() (JiraUser, error) {
+		var user JiraUser
+		err := jiraDo(cfg, http.MethodGet, "/rest/api/3/myself", nil, nil, &user)
+		return user, err
+	})
+}
+
 func assignIssue(cfg Config, issueKey, accountID string) error {
 	u := cfg.Server + "/rest/api/3/issue/" + url.PathEscape(issueKey) + "/assignee"
 
@@ -1050,20 +1509,7 @@ func assignIssue(cfg Config, issueKey, accountID string) error {
 func addComment(cfg Config, issueKey, text string) error {
 	u := cfg.Server + "/rest/api/3/issue/" + url.PathEscape(issueKey) + "/comment"
 
-	body := JiraCommentRequest{
-		Body: JiraADFDocument{
-			Type:    "doc",
-			Version: 1,
-			Content: []JiraADFParagraph{
-				{
-					Type: "paragraph",
-					Content: []JiraADFText{
-						{Type: "text", Text: text},
-					},
-				},
-			},
-		},
-	}
+	body := JiraCommentRequest{Body: textToADF(text)}
 	b, err := json.Marshal(body)
 	if err != nil {
 		return err
@@ -1107,12 +1553,136 @@ func addComment(cfg Config, issueKey, text string) error {
 	return nil
 }
 
+type JiraCreatedIssue struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Self string `json:"self"`
+}
+
+func createIssue(cfg Config, fields map[string]any) (JiraCreatedIssue, error) {
+	var created JiraCreatedIssue
+	err := jiraDo(cfg, http.MethodPost, "/rest/api/3/issue", nil, map[string]any{"fields": fields}, &created)
+	return created, err
+}
+
+// transitionIssue moves an issue to the transition best matching status,
+// sending screen fields and the comment from opts in the same request.
+// Transitions are matched per issue because workflows differ, by name only
+// unless opts.MatchTargets is set.
+func transitionIssue(cfg Config, issueKey, status string, opts TransitionOptions, dryRun bool) (TransitionResult, error) {
+	transitions, err := getTransitions(cfg, issueKey)
+	if err != nil {
+		return TransitionResult{}, err
+	}
+
+	match := matchTransition
+	if opts.MatchTargets {
+		match = matchTransitionOrTarget
+	}
+	matched, matchedBy, warning, err := match(transitions, status)
+	if err != nil {
+		return TransitionResult{}, err
+	}
+
+	fields, update, err := buildTransitionFields(cfg, issueKey, matched, opts)
+	if err != nil {
+		return TransitionResult{}, err
+	}
+
+	if !dryRun {
+		if err := doTransition(cfg, issueKey, matched.ID, fields, update); err != nil {
+			return TransitionResult{}, err
+		}
+	}
+
+	return TransitionResult{
+		Key:       issueKey,
+		Status:    matched.Name,
+		MatchedBy: matchedBy,
+		Warning:   warning,
+		Fields:    sortedKeys(fields),
+		Comment:   opts.Comment,
+		DryRun:    dryRun,
+		URL:       cfg.Server + "/browse/" + issueKey,
+	}, nil
+}
+
+// assignIssueTo assigns an issue to user, or to its reporter when user is nil.
+func assignIssueTo(cfg Config, issueKey string, user *JiraUser, dryRun bool) (AssignResult, error) {
+	if user == nil {
+		issue, err := getIssue(cfg, issueKey)
+		if err != nil {
+			return AssignResult{}, err
+		}
+		if issue.Fields.Reporter == nil || issue.Fields.Reporter.AccountID == "" {
+			return AssignResult{}, errors.New("issue has no reporter; use --email to specify an assignee")
+		}
+		user = issue.Fields.Reporter
+	}
+
+	if !dryRun {
+		if err := assignIssue(cfg, issueKey, user.AccountID); err != nil {
+			return AssignResult{}, err
+		}
+	}
+
+	return AssignResult{
+		Key:          issueKey,
+		Assignee:     user.EmailAddress,
+		AssigneeName: user.DisplayName,
+		DryRun:       dryRun,
+		URL:          cfg.Server + "/browse/" + issueKey,
+	}, nil
+}
+
+func commentOnIssue(cfg Config, issueKey, body string, dryRun bool) (CommentResult, error) {
+	if !dryRun {
+		if err := addComment(cfg, issueKey, body); err != nil {
+			return CommentResult{}, err
+		}
+	}
+	return CommentResult{
+		Key:     issueKey,
+		Comment: body,
+		DryRun:  dryRun,
+		URL:     cfg.Server + "/browse/" + issueKey,
+	}, nil
+}
+
+// editIssue sets fields and applies update operations (add/remove/set) on an
+// issue in a single request.
+func editIssue(cfg Config, issueKey string, fields map[string]any, update map[string][]map[string]any) error {
+	body := map[string]any{}
+	if len(fields) > 0 {
+		body["fields"] = fields
+	}
+	if len(update) > 0 {
+		body["update"] = update
+	}
+	return jiraDo(cfg, http.MethodPut, "/rest/api/3/issue/"+url.PathEscape(issueKey), nil, body, nil)
+}
+
 // ---------------------------------------------------------------------------
 // HTTP / API helpers
 // ---------------------------------------------------------------------------
 
+var (
+	httpClientsMu sync.Mutex
+	httpClients   = map[string]*http.Client{}
+)
+
+// buildHTTPClient returns one client per server and credentials, so commands
+// that make many requests (bulk, batch) reuse connections. Clients are never
+// modified after creation.
 func buildHTTPClient(server, email, token string) *http.Client {
-	return &http.Client{
+	id := server + "\x00" + email + "\x00" + token
+
+	httpClientsMu.Lock()
+	defer httpClientsMu.Unlock()
+	if c, ok := httpClients[id]; ok {
+		return c
+	}
+	c := &http.Client{
 		Timeout: defaultHTTPTimeout,
 		Transport: &basicAuthTransport{
 			email: email,
@@ -1120,15 +1690,22 @@ func buildHTTPClient(server, email, token string) *http.Client {
 			base:  http.DefaultTransport,
 		},
 	}
+	httpClients[id] = c
+	return c
 }
 
 type basicAuthTransport struct {
 	email string
 	token string
-	base  http.RoundTripper
+	// host, when set, limits credentials to requests for that host.
+	host string
+	base http.RoundTripper
 }
 
 func (t *basicAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.host != "" && req.URL.Host != t.host {
+		return t.base.RoundTrip(req)
+	}
 	r := req.Clone(req.Context())
 	creds := base64.StdEncoding.EncodeToString([]byte(t.email + ":" + t.token))
 	r.Header.Set("Authorization", "Basic "+creds)
@@ -1139,27 +1716,100 @@ func decodeAPIResponse(resp *http.Response, out any) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode >= 300 {
-		body, _ := io.ReadAll(resp.Body)
-		trimmed := strings.TrimSpace(string(body))
+		return apiError(resp)
+	}
 
-		var apiErr JiraAPIError
-		if err := json.Unmarshal(body, &apiErr); err == nil {
-			msgs := apiErr.ErrorMessages
-			for k, v := range apiErr.Errors {
-				msgs = append(msgs, fmt.Sprintf("%s: %s", k, v))
-			}
-			if len(msgs) > 0 {
-				return fmt.Errorf("jira api error (%s): %s", resp.Status, strings.Join(msgs, "; "))
-			}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// jiraHTTPError is a non-2xx response from Jira. StatusCode lets callers
+// tell a permission or not-found failure from a transient one.
+type jiraHTTPError struct {
+	StatusCode int
+	msg        string
+}
+
+func (e *jiraHTTPError) Error() string { return e.msg }
+
+// isHTTPStatus reports whether err is a Jira response with one of codes.
+func isHTTPStatus(err error, codes ...int) bool {
+	var he *jiraHTTPError
+	if !errors.As(err, &he) {
+		return false
+	}
+	for _, c := range codes {
+		if he.StatusCode == c {
+			return true
 		}
+	}
+	return false
+}
 
-		if trimmed == "" {
-			trimmed = resp.Status
+// apiError turns a non-2xx response into an error, preferring Jira's
+// structured error messages over the raw body.
+func apiError(resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+	trimmed := strings.TrimSpace(string(body))
+
+	var apiErr JiraAPIError
+	if err := json.Unmarshal(body, &apiErr); err == nil {
+		msgs := apiErr.ErrorMessages
+		for k, v := range apiErr.Errors {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", k, v))
+		}
+		if len(msgs) > 0 {
+			return &jiraHTTPError{resp.StatusCode, fmt.Sprintf("jira api error (%s): %s", resp.Status, strings.Join(msgs, "; "))}
 		}
-		return fmt.Errorf("jira api error (%s): %s", resp.Status, trimmed)
 	}
 
-	return json.NewDecoder(resp.Body).Decode(out)
+	if trimmed == "" {
+		trimmed = resp.Status
+	}
+	return &jiraHTTPError{resp.StatusCode, fmt.Sprintf("jira api error (%s): %s", resp.Status, trimmed)}
+}
+
+// jiraDo sends a request to a server-relative path. body is JSON-encoded when
+// non-nil; the response is decoded into out when out is non-nil and discarded
+// otherwise.
+func jiraDo(cfg Config, method, path string, query url.Values, body, out any) error {
+	u := cfg.Server + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	client := buildHTTPClient(cfg.Server, cfg.Email, cfg.APIToken)
+	req, err := http.NewRequest(method, u, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("jira api request failed: %w", err)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		defer resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return apiError(resp)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	return decodeAPIResponse(resp, out)
 }
 
 // ---------------------------------------------------------------------------
@@ -1267,18 +1917,28 @@ func loadAuthConfig() (Config, error) {
 
 func issueToView(issue JiraIssue, server string) IssueView {
 	return IssueView{
-		Key:      issue.Key,
-		Summary:  issue.Fields.Summary,
-		Status:   nameOrEmpty(issue.Fields.Status),
-		Type:     nameOrEmpty(issue.Fields.IssueType),
-		Priority: nameOrEmpty(issue.Fields.Priority),
-		Assignee: userEmail(issue.Fields.Assignee),
-		Created:  formatDate(issue.Fields.Created),
-		Updated:  formatDate(issue.Fields.Updated),
-		URL:      server + "/browse/" + issue.Key,
+		Key:        issue.Key,
+		Summary:    issue.Fields.Summary,
+		Status:     nameOrEmpty(issue.Fields.Status),
+		Type:       nameOrEmpty(issue.Fields.IssueType),
+		Priority:   nameOrEmpty(issue.Fields.Priority),
+		Assignee:   userEmail(issue.Fields.Assignee),
+		Labels:     issue.Fields.Labels,
+		Components: componentNames(issue.Fields.Components),
+		Created:    formatDate(issue.Fields.Created),
+		Updated:    formatDate(issue.Fields.Updated),
+		URL:        server + "/browse/" + issue.Key,
 	}
 }
 
+func componentNames(components []JiraNameField) []string {
+	var names []string
+	for _, c := range components {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
 func issuesToViews(issues []JiraIssue, server string) []IssueView {
 	views := make([]IssueView, len(issues))
 	for i, issue := range issues {
@@ -1291,6 +1951,15 @@ func issueToDetailView(issue JiraIssue, server string, comments []JiraComment) I
 	dv := IssueDetailView{
 		IssueView:   issueToView(issue, server),
 		Description: adfToText(issue.Fields.Description),
+		Links:       issueLinksToViews(issue.Fields.IssueLinks),
+		Attachments: attachmentsToViews(issue.Fields.Attachments),
+	}
+	if p := issue.Fields.Parent; p != nil && p.Key != "" {
+		ref := linkedIssueToRef(*p)
+		dv.Parent = &ref
+	}
+	for _, st := range issue.Fields.Subtasks {
+		dv.Children = append(dv.Children, linkedIssueToRef(st))
 	}
 	for _, c := range comments {
 		dv.Comments = append(dv.Comments, CommentView{
@@ -1312,6 +1981,30 @@ func userDisplayName(u *JiraUser) string {
 	return u.EmailAddress
 }
 
+// textToADF converts plain text to an Atlassian Document Format document.
+// Blank lines separate paragraphs; single newlines become hard breaks.
+func textToADF(text string) JiraADFDocument {
+	doc := JiraADFDocument{Type: "doc", Version: 1, Content: []JiraADFParagraph{}}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	for _, para := range strings.Split(text, "\n\n") {
+		para = strings.Trim(para, "\n")
+		if strings.TrimSpace(para) == "" {
+			continue
+		}
+		p := JiraADFParagraph{Type: "paragraph"}
+		for i, line := range strings.Split(para, "\n") {
+			if i > 0 {
+				p.Content = append(p.Content, JiraADFText{Type: "hardBreak"})
+			}
+			if line != "" {
+				p.Content = append(p.Content, JiraADFText{Type: "text", Text: line})
+			}
+		}
+		doc.Content = append(doc.Content, p)
+	}
+	return doc
+}
+
 // adfToText extracts plain text from Jira's Atlassian Document Format.
 func adfToText(v any) string {
 	if v == nil {
@@ -1334,6 +2027,9 @@ func adfExtract(node map[string]any, sb *strings.Builder) {
 	if text, ok := node["text"].(string); ok {
 		sb.WriteString(text)
 	}
+	if node["type"] == "hardBreak" {
+		sb.WriteString("\n")
+	}
 
 	// Recurse into content array
 	content, ok := node["content"].([]any)
@@ -1402,6 +2098,17 @@ func formatDate(s string) string {
 	return t.Format("2006-01-02")
 }
 
+// parseJiraTime parses the timestamp formats returned by the platform
+// ("2006-01-02T15:04:05.000-0700") and agile (RFC 3339) APIs.
+func parseJiraTime(s string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02T15:04:05.000-0700", "2006-01-02T15:04:05.999-0700", time.RFC3339Nano} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid jira timestamp %q", s)
+}
+
 // ---------------------------------------------------------------------------
 // JSON output
 // ---------------------------------------------------------------------------
@@ -1438,17 +2145,58 @@ func printJSON(v any) error {
 	return emitJSONRaw(v)
 }
 
+// reportedError wraps a failure whose details a command already printed as
+// JSON on stdout, so main exits non-zero without printing a second document.
+type reportedError struct{ err error }
+
+func (e reportedError) Error() string { return e.err.Error() }
+
+func (e reportedError) Unwrap() error { return e.err }
+
+// printPartialJSON prints v for a command that works through several issues
+// and may have failed on some of them. On failure the envelope is ok:false
+// with the data next to the error, and the returned reportedError makes main
+// exit non-zero without printing a second document.
+func printPartialJSON(v any, failure error) error {
+	if failure == nil {
+		return printJSON(v)
+	}
+	var err error
+	if jsonEnvelopeEnabled() {
+		err = emitJSONRaw(map[string]any{
+			"ok":   false,
+			"data": v,
+			"error": map[string]any{
+				"code":    "PARTIAL_FAILURE",
+				"message": failure.Error(),
+			},
+		})
+	} else {
+		err = emitJSONRaw(v)
+	}
+	if err != nil {
+		return err
+	}
+	return reportedError{failure}
+}
+
 func printJSONError(err error) error {
 	msg := "unknown error"
 	if err != nil {
 		msg = err.Error()
 	}
+	payload := map[string]any{
+		"code":    "FATAL",
+		"message": msg,
+	}
+	var ce codedError
+	if errors.As(err, &ce) {
+		payload["code"] = ce.Code()
+		payload["details"] = ce.Details()
+	}
 	return emitJSONRaw(map[string]any{
-		"ok": false,
-		"error": map[string]any{
-			"code":    "FATAL",
-			"message": msg,
-		},
+		"ok":    false,
+		"error": payload,
 	})
 }
 
@@ -1477,6 +2225,87 @@ func firstNonEmpty(values ...string) string {
 	return ""
 }
 
+// stringList is a repeatable flag that also splits comma-separated values.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(v string) error {
+	for _, part := range strings.Split(v, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			*l = append(*l, part)
+		}
+	}
+	return nil
+}
+
+// rawList is a repeatable flag that keeps each value whole, for values that
+// may themselves contain commas.
+type rawList []string
+
+func (l *rawList) String() string {
+	return strings.Join(*l, " ")
+}
+
+func (l *rawList) Set(v string) error {
+	*l = append(*l, v)
+	return nil
+}
+
+// parseFlags parses fs allowing flags before, between and after positional
+// arguments (e.g. "issues view PROJ-1 --json") and returns the positionals.
+// flag.Parse stops at the first positional, which silently dropped flags
+// written after an issue key, so commands taking keys use this instead.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		rest := fs.Args()
+		// After "--" everything is positional, even if it looks like a flag.
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil
+		}
+		if len(rest) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// compareIssueKeys orders issue keys by project, then numerically by issue
+// number, so PROJ-9 sorts before PROJ-10.
+func compareIssueKeys(a, b string) int {
+	pa, na := splitIssueKey(a)
+	pb, nb := splitIssueKey(b)
+	if pa != pb {
+		return strings.Compare(pa, pb)
+	}
+	if na != nb {
+		if na < nb {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func splitIssueKey(key string) (string, int) {
+	i := strings.LastIndex(key, "-")
+	if i < 0 {
+		return key, 0
+	}
+	n, err := strconv.Atoi(key[i+1:])
+	if err != nil {
+		return key, 0
+	}
+	return key[:i], n
+}
+
 func minInt(a, b int) int {
 	if a < b {
 		return a
@@ -1484,69 +2313,137 @@ func minInt(a, b int) int {
 	return b
 }
 
+// matchTransition picks the transition whose name best matches targetStatus.
 func matchTransition(transitions []JiraTransition, targetStatus string) (JiraTransition, string, string, error) {
+	return matchTransitionBy(transitions, targetStatus, false)
+}
+
+// matchTransitionOrTarget is matchTransition that also matches the status
+// each transition leads to, since workflows name transitions differently
+// ("Resolve", "Close"). Bulk transitions and --path auto use it, as they
+// act on issues whose workflows may differ.
+func matchTransitionOrTarget(transitions []JiraTransition, targetStatus string) (JiraTransition, string, string, error) {
+	return matchTransitionBy(transitions, targetStatus, true)
+}
+
+func matchTransitionBy(transitions []JiraTransition, targetStatus string, matchTargets bool) (JiraTransition, string, string, error) {
 	query := strings.TrimSpace(targetStatus)
 	if query == "" {
 		return JiraTransition{}, "", "", errors.New("--status is required")
 	}
 
+	names := make([]string, len(transitions))
+	for i, t := range transitions {
+		names[i] = t.Name
+	}
+
+	nameIndexes, nameTier := fuzzyMatchNames(names, query)
+	var targetIndexes []int
+	var targetTier string
+	if matchTargets {
+		targets := make([]string, len(transitions))
+		for i, t := range transitions {
+			targets[i] = nameOrEmpty(t.To)
+		}
+		targetIndexes, targetTier = fuzzyMatchNames(targets, query)
+	}
+
+	pick := func(indexes []int, matchedBy string) (JiraTransition, string, string, error) {
+		candidates := make([]JiraTransition, 0, len(indexes))
+		for _, i := range indexes {
+			candidates = append(candidates, transitions[i])
+		}
+		picked := pickBestTransition(candidates)
+		if matchedBy == "exact" {
+			return picked, matchedBy, "", nil
+		}
+		return picked, matchedBy, ambiguityWarning(query, candidates, picked), nil
+	}
+
+	// An exact name wins, then an exact target status, so "Done" picks
+	// "Close" (-> Done) over "Done with QA" (-> QA).
+	switch {
+	case len(nameIndexes) > 0 && nameTier == "exact":
+		return pick(nameIndexes, nameTier)
+	case len(targetIndexes) > 0 && targetTier == "exact":
+		return pick(targetIndexes, "target_exact")
+	case len(nameIndexes) > 0:
+		return pick(nameIndexes, nameTier)
+	case len(targetIndexes) > 0:
+		return pick(targetIndexes, "target_"+targetTier)
+	}
+
+	return JiraTransition{}, "", "", fmt.Errorf("no transition matching %q; available transitions: %s", query, strings.Join(transitionLabels(transitions), ", "))
+}
+
+// transitionLabels describes transitions as "Name" or "Name (-> Status)"
+// when the target status differs from the transition name.
+func transitionLabels(transitions []JiraTransition) []string {
+	labels := make([]string, len(transitions))
+	for i, t := range transitions {
+		labels[i] = t.Name
+		if to := nameOrEmpty(t.To); to != "" && !strings.EqualFold(to, t.Name) {
+			labels[i] = fmt.Sprintf("%s (-> %s)", t.Name, to)
+		}
+	}
+	return labels
+}
+
+// fuzzyMatchNames finds the names matching query in the first non-empty tier
+// of exact, prefix and substring matches (all case-insensitive). It returns
+// the matching indexes and the tier name; substring matches are ordered by
+// match position.
+func fuzzyMatchNames(names []string, query string) ([]int, string) {
 	type scoredMatch struct {
-		transition JiraTransition
-		score      int
+		index int
+		score int
 	}
 
-	var exact []JiraTransition
-	var prefix []JiraTransition
+	var exact []int
+	var prefix []int
 	var contains []scoredMatch
 	queryLower := strings.ToLower(query)
 
-	for _, t := range transitions {
-		nameLower := strings.ToLower(t.Name)
+	for i, name := range names {
+		nameLower := strings.ToLower(name)
 		switch {
-		case strings.EqualFold(t.Name, query):
-			exact = append(exact, t)
+		case strings.EqualFold(name, query):
+			exact = append(exact, i)
 		case strings.HasPrefix(nameLower, queryLower):
-			prefix = append(prefix, t)
+			prefix = append(prefix, i)
 		case strings.Contains(nameLower, queryLower):
 			contains = append(contains, scoredMatch{
-				transition: t,
-				score:      strings.Index(nameLower, queryLower),
+				index: i,
+				score: strings.Index(nameLower, queryLower),
 			})
 		}
 	}
 
 	if len(exact) > 0 {
-		return pickBestTransition(exact), "exact", "", nil
+		return exact, "exact"
 	}
 	if len(prefix) > 0 {
-		picked := pickBestTransition(prefix)
-		return picked, "prefix", ambiguityWarning(query, prefix, picked), nil
+		return prefix, "prefix"
 	}
 	if len(contains) > 0 {
 		sort.Slice(contains, func(i, j int) bool {
 			if contains[i].score != contains[j].score {
 				return contains[i].score < contains[j].score
 			}
-			ni := strings.ToLower(contains[i].transition.Name)
-			nj := strings.ToLower(contains[j].transition.Name)
+			ni := strings.ToLower(names[contains[i].index])
+			nj := strings.ToLower(names[contains[j].index])
 			if ni != nj {
 				return ni < nj
 			}
-			return len(contains[i].transition.Name) < len(contains[j].transition.Name)
+			return len(names[contains[i].index]) < len(names[contains[j].index])
 		})
-		candidates := make([]JiraTransition, 0, len(contains))
+		indexes := make([]int, 0, len(contains))
 		for _, c := range contains {
-			candidates = append(candidates, c.transition)
+			indexes = append(indexes, c.index)
 		}
-		picked := pickBestTransition(candidates)
-		return picked, "contains", ambiguityWarning(query, candidates, picked), nil
-	}
-
-	var available []string
-	for _, t := range transitions {
-		available = append(available, t.Name)
+		return indexes, "contains"
 	}
-	return JiraTransition{}, "", "", fmt.Errorf("no transition matching %q; available transitions: %s", query, strings.Join(available, ", "))
+	return nil, ""
 }
 
 func pickBestTransition(candidates []JiraTransition) JiraTransition {
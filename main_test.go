@@ -2,12 +2,21 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
 )
 
+// TestMain disables the metadata cache so tests never read or write the
+// user's cache; cache tests re-enable it against a temporary config dir.
+func TestMain(m *testing.M) {
+	os.Setenv("JIRACTL_NO_CACHE", "1")
+	os.Exit(m.Run())
+}
+
 func TestMatchTransitionExactWins(t *testing.T) {
 	transitions := []JiraTransition{
 		{ID: "1", Name: "Done (QA)"},
@@ -50,6 +59,42 @@ func TestMatchTransitionPrefixAmbiguityWarns(t *testing.T) {
 	}
 }
 
+func TestMatchTransitionExactTargetBeatsPartialName(t *testing.T) {
+	transitions := []JiraTransition{
+		{ID: "1", Name: "Done with QA", To: &JiraNameField{Name: "QA"}},
+		{ID: "2", Name: "Close", To: &JiraNameField{Name: "Done"}},
+	}
+
+	matched, matchedBy, _, err := matchTransitionOrTarget(transitions, "Done")
+	if err != nil {
+		t.Fatalf("matchTransitionOrTarget returned error: %v", err)
+	}
+	if matched.Name != "Close" {
+		t.Fatalf("expected 'Close' (-> Done), got %q", matched.Name)
+	}
+	if matchedBy != "target_exact" {
+		t.Fatalf("expected matchedBy=target_exact, got %q", matchedBy)
+	}
+}
+
+func TestMatchTransitionIgnoresTargetStatuses(t *testing.T) {
+	transitions := []JiraTransition{
+		{ID: "1", Name: "Done with QA", To: &JiraNameField{Name: "QA"}},
+		{ID: "2", Name: "Close", To: &JiraNameField{Name: "Done"}},
+	}
+
+	matched, matchedBy, _, err := matchTransition(transitions, "Done")
+	if err != nil {
+		t.Fatalf("matchTransition returned error: %v", err)
+	}
+	if matched.Name != "Done with QA" || matchedBy != "prefix" {
+		t.Fatalf("expected a name match on 'Done with QA', got %q (%s)", matched.Name, matchedBy)
+	}
+	if _, _, _, err := matchTransition(transitions[1:], "Done"); err == nil {
+		t.Fatal("expected no match when only a target status matches")
+	}
+}
+
 func TestMatchTransitionNoMatchIncludesAvailable(t *testing.T) {
 	transitions := []JiraTransition{
 		{ID: "1", Name: "In Progress"},
@@ -126,6 +171,50 @@ func TestGetCommentsRespectsLimit(t *testing.T) {
 	}
 }
 
+func TestParseFlagsAllowsFlagsAfterPositionals(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	status := fs.String("status", "", "")
+	jsonOut := fs.Bool("json", false, "")
+
+	positional, err := parseFlags(fs, []string{"PROJ-1", "--status", "Done", "PROJ-2", "--json"})
+	if err != nil {
+		t.Fatalf("parseFlags returned error: %v", err)
+	}
+	if *status != "Done" || !*jsonOut {
+		t.Fatalf("expected flags to be parsed, got status=%q json=%v", *status, *jsonOut)
+	}
+	if strings.Join(positional, ",") != "PROJ-1,PROJ-2" {
+		t.Fatalf("unexpected positionals %v", positional)
+	}
+
+	positional, err = parseFlags(fs, []string{"--json", "--", "-a", "-b", "--status"})
+	if err != nil {
+		t.Fatalf("parseFlags returned error after --: %v", err)
+	}
+	if strings.Join(positional, ",") != "-a,-b,--status" {
+		t.Fatalf("expected everything after -- to be positional, got %v", positional)
+	}
+}
+
+func TestTextToADFRoundTrip(t *testing.T) {
+	doc := textToADF("First line\nsecond line\n\nNext paragraph")
+	if got := len(doc.Content); got != 2 {
+		t.Fatalf("expected 2 paragraphs, got %d", got)
+	}
+
+	b, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var generic map[string]any
+	if err := json.Unmarshal(b, &generic); err != nil {
+		t.Fatal(err)
+	}
+	if got := adfToText(generic); got != "First line\nsecond line\nNext paragraph" {
+		t.Fatalf("unexpected round-trip text %q", got)
+	}
+}
+
 func writeJSON(t *testing.T, w http.ResponseWriter, v any) {
 	t.Helper()
 	w.Header().Set("Content-Type", "application/json")
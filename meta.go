@@ -0,0 +1,314 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// maxListedValues caps the allowed values printed per field in text output.
+const maxListedValues = 12
+
+// ---------------------------------------------------------------------------
+// Jira API response types
+// ---------------------------------------------------------------------------
+
+// JiraIssueTypeStatuses is one entry of /project/{key}/statuses.
+type JiraIssueTypeStatuses struct {
+	ID       string       `json:"id"`
+	Name     string       `json:"name"`
+	Subtask  bool         `json:"subtask"`
+	Statuses []JiraStatus `json:"statuses"`
+}
+
+type JiraPriority struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type JiraCreateMetaResponse struct {
+	Fields []JiraFieldMeta `json:"fields"`
+}
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type StatusView struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	// IssueTypes lists the issue types whose workflow uses the status
+	// (--project only).
+	IssueTypes []string `json:"issue_types,omitempty"`
+}
+
+type PriorityView struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type CreateMetaView struct {
+	Project   string          `json:"project"`
+	IssueType string          `json:"issue_type"`
+	Fields    []FieldMetaView `json:"fields"`
+}
+
+// ---------------------------------------------------------------------------
+// Help functions
+// ---------------------------------------------------------------------------
+
+func printMetaHelp() {
+	fmt.Println("jiractl meta commands:")
+	fmt.Println("  meta statuses    [--project KEY] [--json]")
+	fmt.Println("  meta priorities  [--json]")
+	fmt.Println("  meta createmeta  --project KEY --type TYPE [--json]")
+}
+
+// ---------------------------------------------------------------------------
+// Meta commands
+// ---------------------------------------------------------------------------
+
+func runMeta(args []string) error {
+	if len(args) == 0 {
+		printMetaHelp()
+		return nil
+	}
+
+	switch args[0] {
+	case "statuses":
+		return runMetaStatuses(args[1:])
+	case "priorities":
+		return runMetaPriorities(args[1:])
+	case "createmeta":
+		return runMetaCreateMeta(args[1:])
+	case "help", "--help", "-h":
+		printMetaHelp()
+		return nil
+	default:
+		printMetaHelp()
+		return fmt.Errorf("unknown meta command %q", args[0])
+	}
+}
+
+func runMetaStatuses(args []string) error {
+	fs := flag.NewFlagSet("meta statuses", flag.ContinueOnError)
+	project := fs.String("project", "", "only statuses used by this project's workflows")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	var statuses []StatusView
+	if *project != "" {
+		byType, err := getProjectStatuses(cfg, strings.ToUpper(*project))
+		if err != nil {
+			return err
+		}
+		statuses = mergeProjectStatuses(byType)
+	} else {
+		all, err := getStatuses(cfg)
+		if err != nil {
+			return err
+		}
+		for _, s := range all {
+			statuses = append(statuses, statusToView(s))
+		}
+		sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	}
+
+	if *jsonOut {
+		return printJSON(map[string]any{"project": strings.ToUpper(*project), "statuses": statuses})
+	}
+
+	for _, s := range statuses {
+		line := fmt.Sprintf("- %-20s  %-12s", s.Name, s.Category)
+		if len(s.IssueTypes) > 0 {
+			line += "  " + strings.Join(s.IssueTypes, ", ")
+		}
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	return nil
+}
+
+func runMetaPriorities(args []string) error {
+	fs := flag.NewFlagSet("meta priorities", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	priorities, err := getPriorities(cfg)
+	if err != nil {
+		return err
+	}
+	views := make([]PriorityView, 0, len(priorities))
+	for _, p := range priorities {
+		views = append(views, PriorityView{ID: p.ID, Name: p.Name, Description: p.Description})
+	}
+
+	if *jsonOut {
+		return printJSON(map[string]any{"priorities": views})
+	}
+	for _, p := range views {
+		fmt.Printf("- %-12s  %s\n", p.Name, p.Description)
+	}
+	return nil
+}
+
+func runMetaCreateMeta(args []string) error {
+	fs := flag.NewFlagSet("meta createmeta", flag.ContinueOnError)
+	project := fs.String("project", "", "project key (required)")
+	issueType := fs.String("type", "", "issue type name, e.g. Bug (required)")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *project == "" || *issueType == "" {
+		return errors.New("--project and --type are required (e.g. --project PROJ --type Bug)")
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	view, err := getCreateMeta(cfg, strings.ToUpper(*project), *issueType)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		return printJSON(view)
+	}
+
+	fmt.Printf("Create fields for %s %s:\n", view.Project, view.IssueType)
+	required := true
+	fmt.Println("\nRequired:")
+	for _, f := range view.Fields {
+		if required && !f.Required {
+			required = false
+			fmt.Println("\nOptional:")
+		}
+		line := fmt.Sprintf("  %-20s  %-24s  %s", f.ID, f.Name, f.Type)
+		if f.HasDefault {
+			line += " (has default)"
+		}
+		if n := len(f.AllowedValues); n > 0 {
+			shown := f.AllowedValues[:minInt(n, maxListedValues)]
+			line += "  one of: " + strings.Join(shown, ", ")
+			if n > maxListedValues {
+				line += fmt.Sprintf(", ... (%d more)", n-maxListedValues)
+			}
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Meta helpers
+// ---------------------------------------------------------------------------
+
+func getProjectStatuses(cfg Config, projectKey string) ([]JiraIssueTypeStatuses, error) {
+	return cachedGet(cfg, "statuses", projectKey, func() ([]JiraIssueTypeStatuses, error) {
+		var byType []JiraIssueTypeStatuses
+		err := jiraDo(cfg, http.MethodGet, "/rest/api/3/project/"+url.PathEscape(projectKey)+"/statuses", nil, nil, &byType)
+		return byType, err
+	})
+}
+
+func getPriorities(cfg Config) ([]JiraPriority, error) {
+	return cachedGet(cfg, "priorities", "", func() ([]JiraPriority, error) {
+		var priorities []JiraPriority
+		err := jiraDo(cfg, http.MethodGet, "/rest/api/3/priority", nil, nil, &priorities)
+		return priorities, err
+	})
+}
+
+// mergeProjectStatuses flattens the per-issue-type status lists into one list
+// of distinct statuses, each naming the issue types that use it.
+func mergeProjectStatuses(byType []JiraIssueTypeStatuses) []StatusView {
+	var out []StatusView
+	index := map[string]int{}
+	for _, t := range byType {
+		for _, s := range t.Statuses {
+			i, ok := index[s.ID]
+			if !ok {
+				i = len(out)
+				index[s.ID] = i
+				out = append(out, statusToView(s))
+			}
+			out[i].IssueTypes = append(out[i].IssueTypes, t.Name)
+		}
+	}
+	return out
+}
+
+func statusToView(s JiraStatus) StatusView {
+	view := StatusView{ID: s.ID, Name: s.Name}
+	if s.StatusCategory != nil {
+		view.Category = s.StatusCategory.Name
+	}
+	return view
+}
+
+// getCreateMeta resolves the issue type by name within the project and lists
+// its create screen fields, required fields first.
+func getCreateMeta(cfg Config, projectKey, typeName string) (CreateMetaView, error) {
+	types, err := getProjectIssueTypes(cfg, projectKey)
+	if err != nil {
+		return CreateMetaView{}, err
+	}
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = t.Name
+	}
+	indexes, _ := fuzzyMatchNames(names, typeName)
+	if len(indexes) == 0 {
+		return CreateMetaView{}, fmt.Errorf("issue type %q not found in %s; available: %s", typeName, projectKey, strings.Join(names, ", "))
+	}
+	if len(indexes) > 1 {
+		var matches []string
+		for _, i := range indexes {
+			matches = append(matches, names[i])
+		}
+		return CreateMetaView{}, fmt.Errorf("issue type %q is ambiguous in %s: %s", typeName, projectKey, strings.Join(matches, ", "))
+	}
+	issueType := types[indexes[0]]
+
+	var resp JiraCreateMetaResponse
+	path := "/rest/api/3/issue/createmeta/" + url.PathEscape(projectKey) + "/issuetypes/" + url.PathEscape(issueType.ID)
+	if err := jiraDo(cfg, http.MethodGet, path, url.Values{"maxResults": {"200"}}, nil, &resp); err != nil {
+		return CreateMetaView{}, err
+	}
+
+	view := CreateMetaView{Project: projectKey, IssueType: issueType.Name, Fields: []FieldMetaView{}}
+	for _, f := range resp.Fields {
+		view.Fields = append(view.Fields, fieldMetaToView(firstNonEmpty(f.FieldID, f.Key), f))
+	}
+	sort.SliceStable(view.Fields, func(i, j int) bool {
+		a, b := view.Fields[i], view.Fields[j]
+		if a.Required != b.Required {
+			return a.Required
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+	return view, nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetCreateMetaListsRequiredFieldsFirst(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/createmeta/PROJ/issuetypes", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, JiraIssueTypesResponse{IssueTypes: []JiraIssueType{{ID: "1", Name: "Task"}, {ID: "2", Name: "Bug"}}})
+	})
+	mux.HandleFunc("/rest/api/3/issue/createmeta/PROJ/issuetypes/2", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, JiraCreateMetaResponse{Fields: []JiraFieldMeta{
+			{FieldID: "labels", Name: "Labels", Schema: JiraFieldSchema{Type: "array", Items: "string"}},
+			{FieldID: "summary", Name: "Summary", Required: true, Schema: JiraFieldSchema{Type: "string"}},
+			{FieldID: "customfield_10050", Name: "Severity", Required: true, Schema: JiraFieldSchema{Type: "option"},
+				AllowedValues: []JiraAllowedValue{{ID: "1", Value: "High"}, {ID: "2", Value: "Low"}}},
+		}})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	view, err := getCreateMeta(cfg, "PROJ", "bug")
+	if err != nil {
+		t.Fatalf("getCreateMeta returned error: %v", err)
+	}
+	if view.IssueType != "Bug" || len(view.Fields) != 3 {
+		t.Fatalf("unexpected view %+v", view)
+	}
+	var ids []string
+	for _, f := range view.Fields {
+		ids = append(ids, f.ID)
+	}
+	if got := strings.Join(ids, ","); got != "customfield_10050,summary,labels" {
+		t.Fatalf("unexpected field order %q", got)
+	}
+	if got := strings.Join(view.Fields[0].AllowedValues, ","); got != "High,Low" {
+		t.Fatalf("unexpected allowed values %q", got)
+	}
+
+	if _, err := getCreateMeta(cfg, "PROJ", "Epic"); err == nil || !strings.Contains(err.Error(), "Task, Bug") {
+		t.Fatalf("expected not-found error listing types, got %v", err)
+	}
+}
+
+func TestMergeProjectStatuses(t *testing.T) {
+	todo := JiraStatus{ID: "1", Name: "To Do", StatusCategory: &JiraStatusCategory{Name: "To Do"}}
+	done := JiraStatus{ID: "3", Name: "Done", StatusCategory: &JiraStatusCategory{Name: "Done"}}
+	merged := mergeProjectStatuses([]JiraIssueTypeStatuses{
+		{Name: "Task", Statuses: []JiraStatus{todo, done}},
+		{Name: "Bug", Statuses: []JiraStatus{todo}},
+	})
+	if len(merged) != 2 || strings.Join(merged[0].IssueTypes, ",") != "Task,Bug" || merged[1].Category != "Done" {
+		t.Fatalf("unexpected merge %+v", merged)
+	}
+}
@@ -0,0 +1,456 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// mirrorFields are the issue fields stored in the mirror: everything
+// "issues view" shows plus the fields the offline JQL subset filters on.
+const mirrorFields = "summary,description,status,issuetype,priority,assignee,reporter,created,updated,labels,components,issuelinks,parent,subtasks,attachment,resolution,fixVersions"
+
+// maxMirrorComments is the number of comments stored per issue.
+const maxMirrorComments = 100
+
+// syncOverlap widens incremental syncs so clock skew and JQL's minute
+// resolution never drop an update.
+const syncOverlap = 5 * time.Minute
+
+var orderByPattern = regexp.MustCompile(`(?is)\s+order\s+by\s+.*$`)
+
+// ---------------------------------------------------------------------------
+// Mirror types
+// ---------------------------------------------------------------------------
+
+// MirrorIssue is one issue file in the mirror.
+type MirrorIssue struct {
+	Issue          JiraIssue     `json:"issue"`
+	StatusCategory string        `json:"status_category,omitempty"`
+	Comments       []JiraComment `json:"comments"`
+	SyncedAt       time.Time     `json:"synced_at"`
+}
+
+// MirrorState records what was synced and when.
+type MirrorState struct {
+	Server  string        `json:"server"`
+	Myself  *JiraUser     `json:"myself,omitempty"`
+	Queries []MirrorQuery `json:"queries"`
+}
+
+type MirrorQuery struct {
+	JQL      string    `json:"jql"`
+	SyncedAt time.Time `json:"synced_at"`
+	// Partial is set when the last sync stopped at --limit; SyncedAt is then
+	// the update time of the last issue stored.
+	Partial bool `json:"partial,omitempty"`
+}
+
+type SyncResult struct {
+	JQL      string   `json:"jql"`
+	Mode     string   `json:"mode"`
+	Fetched  int      `json:"fetched"`
+	Failed   []string `json:"failed,omitempty"`
+	HasMore  bool     `json:"has_more,omitempty"`
+	Mirrored int      `json:"mirrored"`
+	SyncedAt string   `json:"synced_at"`
+}
+
+// mirror is an opened mirror directory for one account.
+type mirror struct {
+	dir   string
+	state MirrorState
+}
+
+// ---------------------------------------------------------------------------
+// Sync command
+// ---------------------------------------------------------------------------
+
+func runSync(args []string) error {
+	fs := flag.NewFlagSet("sync", flag.ContinueOnError)
+	jql := fs.String("jql", "", "JQL selecting the issues to mirror (default: re-sync every mirrored query)")
+	full := fs.Bool("full", false, "fetch every matching issue instead of only those updated since the last sync")
+	limit := fs.Int("limit", 5000, "max issues to fetch per query")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *limit <= 0 {
+		return errors.New("--limit must be greater than 0")
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+	m, err := openMirror(cfg)
+	if err != nil {
+		return err
+	}
+
+	queries := []string{strings.TrimSpace(*jql)}
+	if queries[0] == "" {
+		queries = nil
+		for _, q := range m.state.Queries {
+			queries = append(queries, q.JQL)
+		}
+		if len(queries) == 0 {
+			return errors.New("nothing mirrored yet; pass --jql (e.g. --jql \"project in (A, B)\")")
+		}
+	}
+
+	var results []SyncResult
+	failed := 0
+	for _, q := range queries {
+		result, err := m.sync(cfg, q, *full, *limit, time.Now())
+		if err != nil {
+			return fmt.Errorf("sync %q: %w", q, err)
+		}
+		failed += len(result.Failed)
+		results = append(results, result)
+	}
+
+	var failure error
+	if failed > 0 {
+		failure = fmt.Errorf("%d issues failed to sync; they will be retried on the next sync", failed)
+	}
+	if *jsonOut {
+		return printPartialJSON(map[string]any{"dir": m.dir, "results": results}, failure)
+	}
+	for _, r := range results {
+		fmt.Printf("%s sync of %q: %d issues fetched, %d in mirror (synced at %s)\n", r.Mode, r.JQL, r.Fetched, r.Mirrored, r.SyncedAt)
+		if r.HasMore {
+			fmt.Fprintf(os.Stderr, "warning: more issues match than --limit; run sync again to fetch the rest.\n")
+		}
+	}
+	return failure
+}
+
+// ---------------------------------------------------------------------------
+// Mirror store
+// ---------------------------------------------------------------------------
+
+func mirrorDir(cfg Config) (string, error) {
+	d, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d, "mirror", cacheProfile(cfg)), nil
+}
+
+func openMirror(cfg Config) (*mirror, error) {
+	dir, err := mirrorDir(cfg)
+	if err != nil {
+		return nil, err
+	}
+	m := &mirror{dir: dir, state: MirrorState{Server: cfg.Server}}
+	b, err := os.ReadFile(filepath.Join(dir, "state.json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &m.state); err != nil {
+		return nil, fmt.Errorf("corrupt mirror state %s: %w", filepath.Join(dir, "state.json"), err)
+	}
+	return m, nil
+}
+
+// openOfflineMirror opens a mirror that must already hold synced data.
+func openOfflineMirror(cfg Config) (*mirror, error) {
+	m, err := openMirror(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if len(m.state.Queries) == 0 {
+		return nil, errors.New("no offline mirror yet; run jiractl sync --jql \"...\" while online")
+	}
+	return m, nil
+}
+
+func (m *mirror) saveState() error {
+	return writeFileAtomic(filepath.Join(m.dir, "state.json"), m.state)
+}
+
+func (m *mirror) issuePath(key string) string {
+	return filepath.Join(m.dir, "issues", strings.ToUpper(key)+".json")
+}
+
+func (m *mirror) putIssue(mi MirrorIssue) error {
+	return writeFileAtomic(m.issuePath(mi.Issue.Key), mi)
+}
+
+func (m *mirror) getIssue(key string) (MirrorIssue, error) {
+	var mi MirrorIssue
+	b, err := os.ReadFile(m.issuePath(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return mi, fmt.Errorf("%s is not in the offline mirror (synced at %s)", strings.ToUpper(key), m.syncedAt().Format(time.RFC3339))
+	}
+	if err != nil {
+		return mi, err
+	}
+	err = json.Unmarshal(b, &mi)
+	return mi, err
+}
+
+func (m *mirror) allIssues() ([]MirrorIssue, error) {
+	files, err := filepath.Glob(filepath.Join(m.dir, "issues", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]MirrorIssue, 0, len(files))
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		var mi MirrorIssue
+		if err := json.Unmarshal(b, &mi); err != nil {
+			return nil, fmt.Errorf("corrupt mirror file %s: %w", f, err)
+		}
+		issues = append(issues, mi)
+	}
+	return issues, nil
+}
+
+// syncedAt is the oldest sync time across mirrored queries, so staleness is
+// never understated.
+func (m *mirror) syncedAt() time.Time {
+	var oldest time.Time
+	for _, q := range m.state.Queries {
+		if oldest.IsZero() || q.SyncedAt.Before(oldest) {
+			oldest = q.SyncedAt
+		}
+	}
+	return oldest
+}
+
+// sync fetches the issues matching jql, only those updated since the query's
+// last sync unless full, and stores them with their comments. The sync time
+// only advances when every issue was stored, so failures are retried. When
+// more issues match than limit, it advances to the last issue stored so the
+// next sync picks up where this one stopped.
+func (m *mirror) sync(cfg Config, jql string, full bool, limit int, now time.Time) (SyncResult, error) {
+	base := strings.TrimSpace(orderByPattern.ReplaceAllString(jql, ""))
+	result := SyncResult{JQL: jql, Mode: "full"}
+
+	query := base
+	idx := -1
+	for i, q := range m.state.Queries {
+		if q.JQL == jql {
+			idx = i
+		}
+	}
+	if idx >= 0 && !full {
+		// Resuming a sync cut off by --limit skips the overlap, or a burst
+		// of more than limit updates would be fetched again every time.
+		overlap := syncOverlap
+		if m.state.Queries[idx].Partial {
+			overlap = 0
+		}
+		query = updatedSinceJQL(base, m.state.Queries[idx].SyncedAt, now, overlap)
+		result.Mode = "incremental"
+	}
+
+	found, err := searchIssuesWithFields(cfg, query+" ORDER BY updated ASC", limit, mirrorFields)
+	if err != nil {
+		return result, err
+	}
+	result.Fetched = len(found.Issues)
+	result.HasMore = found.HasMore
+
+	categories := map[string]string{}
+	if statuses, err := getStatuses(cfg); err == nil {
+		for _, s := range statuses {
+			if s.StatusCategory != nil {
+				categories[s.Name] = s.StatusCategory.Name
+			}
+		}
+	}
+	if me, err := getMyself(cfg); err == nil {
+		m.state.Myself = &me
+	}
+
+	keys := make([]string, len(found.Issues))
+	byKey := map[string]JiraIssue{}
+	for i, issue := range found.Issues {
+		keys[i] = issue.Key
+		byKey[issue.Key] = issue
+	}
+	stored := bulkApply(keys, 4, func(key string) (any, error) {
+		comments, err := getComments(cfg, key, maxMirrorComments)
+		if err != nil {
+			return nil, err
+		}
+		issue := byKey[key]
+		return nil, m.putIssue(MirrorIssue{
+			Issue:          issue,
+			StatusCategory: categories[nameOrEmpty(issue.Fields.Status)],
+			Comments:       comments,
+			SyncedAt:       now.UTC(),
+		})
+	})
+	for _, r := range stored {
+		if !r.OK {
+			result.Failed = append(result.Failed, r.Key)
+		}
+	}
+
+	if len(result.Failed) == 0 {
+		syncedAt, advance := now.UTC(), true
+		if n := len(found.Issues); result.HasMore && n > 0 {
+			// Results are oldest first, so resume from the last issue stored.
+			t, err := parseJiraTime(found.Issues[n-1].Fields.Updated)
+			syncedAt, advance = t.UTC(), err == nil
+		}
+		if advance {
+			if idx < 0 {
+				m.state.Queries = append(m.state.Queries, MirrorQuery{JQL: jql})
+				idx = len(m.state.Queries) - 1
+			}
+			m.state.Queries[idx].SyncedAt = syncedAt
+			m.state.Queries[idx].Partial = result.HasMore
+		}
+	}
+	if err := m.saveState(); err != nil {
+		return result, err
+	}
+
+	if idx >= 0 {
+		result.SyncedAt = m.state.Queries[idx].SyncedAt.Format(time.RFC3339)
+	}
+	all, err := filepath.Glob(filepath.Join(m.dir, "issues", "*.json"))
+	if err != nil {
+		return result, err
+	}
+	result.Mirrored = len(all)
+	return result, nil
+}
+
+// updatedSinceJQL narrows jql to issues updated since the given time, less
+// overlap. Relative dates avoid depending on the Jira profile's time zone.
+func updatedSinceJQL(jql string, since, now time.Time, overlap time.Duration) string {
+	minutes := int(now.Sub(since.Add(-overlap)).Minutes()) + 1
+	return fmt.Sprintf("(%s) AND updated >= \"-%dm\"", jql, minutes)
+}
+
+// ---------------------------------------------------------------------------
+// Offline helpers
+// ---------------------------------------------------------------------------
+
+// offlineRequested reports whether a command should answer from the mirror:
+// --offline or JIRACTL_OFFLINE=1.
+func offlineRequested(flagValue bool) bool {
+	if flagValue {
+		return true
+	}
+	switch strings.ToLower(os.Getenv("JIRACTL_OFFLINE")) {
+	case "1", "true", "yes":
+		return true
+	}
+	return false
+}
+
+// searchIssuesOrMirror runs jql against Jira, or against the mirror when
+// offline. The returned sync time is zero for live results.
+func searchIssuesOrMirror(cfg Config, jql string, limit int, offline bool) (SearchIssuesResult, time.Time, error) {
+	if offline {
+		return searchMirror(cfg, jql, limit)
+	}
+	result, err := searchIssues(cfg, jql, limit)
+	return result, time.Time{}, err
+}
+
+// searchMirror answers a JQL query from the mirror.
+func searchMirror(cfg Config, jql string, limit int) (SearchIssuesResult, time.Time, error) {
+	m, err := openOfflineMirror(cfg)
+	if err != nil {
+		return SearchIssuesResult{}, time.Time{}, err
+	}
+	now := time.Now()
+	q, err := parseLocalJQL(jql, now)
+	if err != nil {
+		return SearchIssuesResult{}, time.Time{}, fmt.Errorf("offline JQL: %w", err)
+	}
+	issues, err := m.allIssues()
+	if err != nil {
+		return SearchIssuesResult{}, time.Time{}, err
+	}
+
+	matched := q.run(jqlEnv{me: m.state.Myself, now: now}, issues)
+	result := SearchIssuesResult{Total: len(matched), HasMore: len(matched) > limit}
+	for i := 0; i < len(matched) && i < limit; i++ {
+		result.Issues = append(result.Issues, matched[i].Issue)
+	}
+	return result, m.syncedAt(), nil
+}
+
+// offlineIssueDetail builds the "issues view" output from the mirror.
+// Children are the mirrored issues whose parent is the issue.
+func offlineIssueDetail(cfg Config, issueKey string, commentLimit int) (IssueDetailView, time.Time, error) {
+	m, err := openOfflineMirror(cfg)
+	if err != nil {
+		return IssueDetailView{}, time.Time{}, err
+	}
+	mi, err := m.getIssue(issueKey)
+	if err != nil {
+		return IssueDetailView{}, time.Time{}, err
+	}
+	comments := mi.Comments
+	if len(comments) > commentLimit {
+		comments = comments[:commentLimit]
+	}
+	view := issueToDetailView(mi.Issue, cfg.Server, comments)
+
+	issues, err := m.allIssues()
+	if err != nil {
+		return IssueDetailView{}, time.Time{}, err
+	}
+	q := localQuery{where: &jqlClause{field: "parent", op: "=", values: []jqlValue{{text: mi.Issue.Key}}}}
+	for _, child := range q.run(jqlEnv{}, issues) {
+		if !hasIssueRef(view.Children, child.Issue.Key) {
+			view.Children = append(view.Children, issueToRef(child.Issue))
+		}
+	}
+	return view, m.syncedAt(), nil
+}
+
+// printOfflineNotice marks text output as coming from the mirror.
+func printOfflineNotice(syncedAt time.Time) {
+	age := time.Since(syncedAt).Round(time.Minute)
+	fmt.Printf("OFFLINE: from the local mirror synced %s (%s ago)\n\n", syncedAt.Local().Format("2006-01-02 15:04"), age)
+}
+
+// writeFileAtomic writes v as JSON via a temporary file and rename. Each call
+// gets its own temp file, since sync workers may write concurrently.
+func writeFileAtomic(path string, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(b)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+	}
+	return err
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMirrorSyncIsIncrementalAndServesOfflineSearch(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var queries []string
+	issues := []JiraIssue{
+		{Key: "PROJ-1", Fields: JiraIssueFields{Summary: "Checkout times out", Status: &JiraNameField{Name: "Open"}}},
+		{Key: "PROJ-2", Fields: JiraIssueFields{Summary: "Update docs", Status: &JiraNameField{Name: "Closed"}}},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		jql := r.URL.Query().Get("jql")
+		queries = append(queries, jql)
+		if strings.Contains(jql, "updated >=") {
+			writeJSON(t, w, JiraSearchResponse{Total: 1, Issues: issues[1:]})
+			return
+		}
+		writeJSON(t, w, JiraSearchResponse{Total: 2, Issues: issues})
+	})
+	mux.HandleFunc("/rest/api/3/issue/PROJ-1/comment", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, JiraCommentsResponse{Comments: []JiraComment{{Body: textToADF("seen in prod"), Created: "2026-03-01T10:00:00.000+0000"}}, Total: 1})
+	})
+	mux.HandleFunc("/rest/api/3/issue/PROJ-2/comment", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, JiraCommentsResponse{})
+	})
+	mux.HandleFunc("/rest/api/3/status", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, []JiraStatus{
+			{Name: "Open", StatusCategory: &JiraStatusCategory{Name: "To Do"}},
+			{Name: "Closed", StatusCategory: &JiraStatusCategory{Name: "Done"}},
+		})
+	})
+	mux.HandleFunc("/rest/api/3/myself", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, JiraUser{AccountID: "acc-1"})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	m, err := openMirror(cfg)
+	if err != nil {
+		t.Fatalf("openMirror returned error: %v", err)
+	}
+	start := time.Now().Add(-time.Hour)
+	first, err := m.sync(cfg, "project = PROJ ORDER BY key", false, 100, start)
+	if err != nil {
+		t.Fatalf("first sync returned error: %v", err)
+	}
+	if first.Mode != "full" || first.Fetched != 2 || first.Mirrored != 2 {
+		t.Fatalf("unexpected first sync %+v", first)
+	}
+
+	m, err = openMirror(cfg)
+	if err != nil {
+		t.Fatalf("reopening mirror returned error: %v", err)
+	}
+	second, err := m.sync(cfg, "project = PROJ ORDER BY key", false, 100, start.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("second sync returned error: %v", err)
+	}
+	if second.Mode != "incremental" || second.Fetched != 1 || second.Mirrored != 2 {
+		t.Fatalf("unexpected second sync %+v", second)
+	}
+	if got := queries[1]; !strings.HasPrefix(got, `(project = PROJ) AND updated >= "-66m"`) {
+		t.Fatalf("unexpected incremental JQL %q", got)
+	}
+
+	result, syncedAt, err := searchMirror(cfg, `text ~ "prod" AND statusCategory = "To Do"`, 10)
+	if err != nil {
+		t.Fatalf("searchMirror returned error: %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Key != "PROJ-1" {
+		t.Fatalf("unexpected offline result %+v", result.Issues)
+	}
+	if !syncedAt.Equal(start.Add(time.Hour)) {
+		t.Fatalf("unexpected synced_at %s", syncedAt)
+	}
+}
+
+func TestMirrorSyncBeyondLimitResumesAfterLastStoredIssue(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var queries []string
+	issues := []JiraIssue{
+		{Key: "PROJ-1", Fields: JiraIssueFields{Summary: "One", Updated: "2026-10-18T11:00:00.000+0000"}},
+		{Key: "PROJ-2", Fields: JiraIssueFields{Summary: "Two", Updated: "2026-10-18T11:30:00.000+0000"}},
+		{Key: "PROJ-3", Fields: JiraIssueFields{Summary: "Three", Updated: "2026-10-18T11:50:00.000+0000"}},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		jql := r.URL.Query().Get("jql")
+		queries = append(queries, jql)
+		if strings.Contains(jql, "updated >=") {
+			writeJSON(t, w, JiraSearchResponse{Total: 1, Issues: issues[2:]})
+			return
+		}
+		writeJSON(t, w, JiraSearchResponse{Total: 3, Issues: issues[:2], NextPageToken: "next"})
+	})
+	mux.HandleFunc("/rest/api/3/", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, JiraCommentsResponse{})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	m, err := openMirror(cfg)
+	if err != nil {
+		t.Fatalf("openMirror returned error: %v", err)
+	}
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	first, err := m.sync(cfg, "project = PROJ", false, 2, now)
+	if err != nil {
+		t.Fatalf("first sync returned error: %v", err)
+	}
+	if !first.HasMore || first.Fetched != 2 || first.SyncedAt != "2026-10-18T11:30:00Z" {
+		t.Fatalf("unexpected first sync %+v", first)
+	}
+	if _, err := openOfflineMirror(cfg); err != nil {
+		t.Fatalf("expected the partly synced query to be mirrored: %v", err)
+	}
+
+	m, err = openMirror(cfg)
+	if err != nil {
+		t.Fatalf("reopening mirror returned error: %v", err)
+	}
+	second, err := m.sync(cfg, "project = PROJ", false, 2, now)
+	if err != nil {
+		t.Fatalf("second sync returned error: %v", err)
+	}
+	if second.Mode != "incremental" || second.HasMore || second.Mirrored != 3 {
+		t.Fatalf("unexpected second sync %+v", second)
+	}
+	if got := queries[1]; got != `(project = PROJ) AND updated >= "-31m" ORDER BY updated ASC` {
+		t.Fatalf("expected a resume without overlap, got %q", got)
+	}
+	if m.state.Queries[0].Partial {
+		t.Fatal("expected the query to be complete after catching up")
+	}
+}
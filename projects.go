@@ -0,0 +1,263 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ---------------------------------------------------------------------------
+// Jira API response types
+// ---------------------------------------------------------------------------
+
+type JiraProject struct {
+	ID             string          `json:"id"`
+	Key            string          `json:"key"`
+	Name           string          `json:"name"`
+	Description    string          `json:"description"`
+	ProjectTypeKey string          `json:"projectTypeKey"`
+	Style          string          `json:"style"`
+	Lead           *JiraUser       `json:"lead"`
+	Components     []JiraNameField `json:"components"`
+	IssueTypes     []JiraIssueType `json:"issueTypes"`
+	Versions       []JiraVersion   `json:"versions"`
+}
+
+type JiraProjectSearchResponse struct {
+	Values []JiraProject `json:"values"`
+	IsLast bool          `json:"isLast"`
+}
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type ProjectView struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Lead string `json:"lead,omitempty"`
+	URL  string `json:"url"`
+}
+
+type ProjectDetailView struct {
+	ProjectView
+	ID          string        `json:"id"`
+	Description string        `json:"description,omitempty"`
+	Style       string        `json:"style,omitempty"`
+	Components  []string      `json:"components"`
+	IssueTypes  []string      `json:"issue_types"`
+	Versions    []VersionView `json:"versions"`
+}
+
+// ---------------------------------------------------------------------------
+// Help functions
+// ---------------------------------------------------------------------------
+
+func printProjectsHelp() {
+	fmt.Println("jiractl projects commands:")
+	fmt.Println("  projects list  [--query TEXT] [--json]")
+	fmt.Println("  projects view  PROJECT-KEY [--json]")
+}
+
+// ---------------------------------------------------------------------------
+// Projects commands
+// ---------------------------------------------------------------------------
+
+func runProjects(args []string) error {
+	if len(args) == 0 {
+		printProjectsHelp()
+		return nil
+	}
+
+	switch args[0] {
+	case "list":
+		return runProjectsList(args[1:])
+	case "view":
+		return runProjectsView(args[1:])
+	case "help", "--help", "-h":
+		printProjectsHelp()
+		return nil
+	default:
+		printProjectsHelp()
+		return fmt.Errorf("unknown projects command %q", args[0])
+	}
+}
+
+func runProjectsList(args []string) error {
+	fs := flag.NewFlagSet("projects list", flag.ContinueOnError)
+	query := fs.String("query", "", "only projects whose key or name contains TEXT")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	projects, err := listProjects(cfg, *query)
+	if err != nil {
+		return err
+	}
+	views := make([]ProjectView, 0, len(projects))
+	for _, p := range projects {
+		views = append(views, projectToView(p, cfg.Server))
+	}
+
+	if *jsonOut {
+		return printJSON(map[string]any{"count": len(views), "projects": views})
+	}
+
+	if len(views) == 0 {
+		fmt.Println("No projects found.")
+		return nil
+	}
+	fmt.Printf("Projects (%d):\n\n", len(views))
+	for _, v := range views {
+		fmt.Printf("- %-10s  %-8s  %s\n", v.Key, v.Type, v.Name)
+	}
+	return nil
+}
+
+func runProjectsView(args []string) error {
+	fs := flag.NewFlagSet("projects view", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("project key is required (e.g. jiractl projects view PROJ)")
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	p, err := getProject(cfg, strings.ToUpper(positional[0]))
+	if err != nil {
+		return err
+	}
+	view := projectToDetailView(p, cfg.Server)
+
+	if *jsonOut {
+		return printJSON(view)
+	}
+
+	fmt.Printf("%s: %s\n", view.Key, view.Name)
+	fmt.Printf("Type: %s", view.Type)
+	if view.Style != "" {
+		fmt.Printf(" (%s)", view.Style)
+	}
+	fmt.Println()
+	fmt.Printf("Lead: %s\n", firstNonEmpty(view.Lead, "-"))
+	if view.Description != "" {
+		fmt.Printf("\n%s\n", view.Description)
+	}
+	fmt.Printf("\nIssue types: %s\n", strings.Join(view.IssueTypes, ", "))
+	fmt.Printf("Components:  %s\n", firstNonEmpty(strings.Join(view.Components, ", "), "-"))
+	var unreleased []string
+	for _, v := range view.Versions {
+		if !v.Released && !v.Archived {
+			unreleased = append(unreleased, v.Name)
+		}
+	}
+	fmt.Printf("Unreleased versions: %s\n", firstNonEmpty(strings.Join(unreleased, ", "), "-"))
+	fmt.Printf("\n%s\n", view.URL)
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Jira API calls
+// ---------------------------------------------------------------------------
+
+// listProjects pages through every project visible to the user.
+func listProjects(cfg Config, query string) ([]JiraProject, error) {
+	return cachedGet(cfg, "projects", "search:"+query, func() ([]JiraProject, error) {
+		return fetchProjects(cfg, query)
+	})
+}
+
+func fetchProjects(cfg Config, query string) ([]JiraProject, error) {
+	var all []JiraProject
+	for {
+		q := url.Values{}
+		q.Set("startAt", strconv.Itoa(len(all)))
+		q.Set("maxResults", "50")
+		q.Set("expand", "lead")
+		if query != "" {
+			q.Set("query", query)
+		}
+		var resp JiraProjectSearchResponse
+		if err := jiraDo(cfg, http.MethodGet, "/rest/api/3/project/search", q, nil, &resp); err != nil {
+			return nil, err
+		}
+		all = append(all, resp.Values...)
+		if resp.IsLast || len(resp.Values) == 0 {
+			return all, nil
+		}
+	}
+}
+
+// getProject loads a project with its lead, components, issue types and
+// versions.
+func getProject(cfg Config, projectKey string) (JiraProject, error) {
+	var p JiraProject
+	q := url.Values{"expand": {"description,lead,issueTypes"}}
+	err := jiraDo(cfg, http.MethodGet, "/rest/api/3/project/"+url.PathEscape(projectKey), q, nil, &p)
+	return p, err
+}
+
+// getProjectKeys lists the keys of all projects visible to the user.
+func getProjectKeys(cfg Config) ([]string, error) {
+	projects, err := listProjects(cfg, "")
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(projects))
+	for _, p := range projects {
+		keys = append(keys, p.Key)
+	}
+	return keys, nil
+}
+
+// ---------------------------------------------------------------------------
+// View helpers
+// ---------------------------------------------------------------------------
+
+func projectToView(p JiraProject, server string) ProjectView {
+	lead := ""
+	if p.Lead != nil {
+		lead = firstNonEmpty(p.Lead.EmailAddress, p.Lead.DisplayName)
+	}
+	return ProjectView{Key: p.Key, Name: p.Name, Type: p.ProjectTypeKey, Lead: lead, URL: server + "/browse/" + p.Key}
+}
+
+func projectToDetailView(p JiraProject, server string) ProjectDetailView {
+	view := ProjectDetailView{
+		ProjectView: projectToView(p, server),
+		ID:          p.ID,
+		Description: strings.TrimSpace(p.Description),
+		Style:       p.Style,
+		Components:  []string{},
+		IssueTypes:  []string{},
+		Versions:    []VersionView{},
+	}
+	for _, c := range p.Components {
+		view.Components = append(view.Components, c.Name)
+	}
+	for _, t := range p.IssueTypes {
+		view.IssueTypes = append(view.IssueTypes, t.Name)
+	}
+	for _, v := range p.Versions {
+		view.Versions = append(view.Versions, versionToView(v))
+	}
+	return view
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestListProjectsPagesUntilLast(t *testing.T) {
+	var starts []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/project/search", func(w http.ResponseWriter, r *http.Request) {
+		start := r.URL.Query().Get("startAt")
+		starts = append(starts, start)
+		if start == "0" {
+			writeJSON(t, w, JiraProjectSearchResponse{Values: []JiraProject{{Key: "ALPHA"}, {Key: "BETA"}}})
+			return
+		}
+		writeJSON(t, w, JiraProjectSearchResponse{Values: []JiraProject{{Key: "GAMMA"}}, IsLast: true})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	keys, err := getProjectKeys(cfg)
+	if err != nil {
+		t.Fatalf("getProjectKeys returned error: %v", err)
+	}
+	if got := strings.Join(keys, ","); got != "ALPHA,BETA,GAMMA" {
+		t.Fatalf("unexpected keys %q", got)
+	}
+	if got := strings.Join(starts, ","); got != "0,2" {
+		t.Fatalf("unexpected startAt sequence %q", got)
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// releaseGroupOrder puts the headings readers look for first; any other
+// group follows alphabetically.
+var releaseGroupOrder = []string{"Features", "Bug fixes", "Tasks"}
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type ReleaseGroup struct {
+	Name   string      `json:"name"`
+	Issues []IssueView `json:"issues"`
+}
+
+type ReleaseNotes struct {
+	Title  string         `json:"title"`
+	Source string         `json:"source"`
+	Count  int            `json:"count"`
+	Groups []ReleaseGroup `json:"groups"`
+	// Missing lists keys referenced by commits that don't exist in Jira
+	// (--git only).
+	Missing []string `json:"missing,omitempty"`
+}
+
+// ---------------------------------------------------------------------------
+// Release notes command
+// ---------------------------------------------------------------------------
+
+func runReleaseNotes(args []string) error {
+	fs := flag.NewFlagSet("release-notes", flag.ContinueOnError)
+	fixVersion := fs.String("fix-version", "", "collect issues with this fix version")
+	gitRange := fs.String("git", "", "collect issues referenced by commits in this range, e.g. v2.2.0..v2.3.0")
+	var projects stringList
+	fs.Var(&projects, "project", "limit to this project (repeatable)")
+	format := fs.String("format", "markdown", "output format: markdown or json")
+	groupBy := fs.String("group-by", "type", "grouping: type or none")
+	templateFile := fs.String("template", "", "render with this Go text/template file instead of --format")
+	title := fs.String("title", "", "title (default: the version or range)")
+	limit := fs.Int("limit", 500, "max issues to include (--fix-version)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if (*fixVersion == "") == (*gitRange == "") {
+		return errors.New("exactly one of --fix-version or --git is required")
+	}
+	if *format != "markdown" && *format != "json" {
+		return fmt.Errorf("unsupported --format %q (use markdown or json)", *format)
+	}
+	if *groupBy != "type" && *groupBy != "none" {
+		return fmt.Errorf("unsupported --group-by %q (use type or none)", *groupBy)
+	}
+	if *limit <= 0 {
+		return errors.New("--limit must be greater than 0")
+	}
+
+	var tmpl *template.Template
+	if *templateFile != "" {
+		var err error
+		tmpl, err = template.ParseFiles(*templateFile)
+		if err != nil {
+			return fmt.Errorf("invalid template: %w", err)
+		}
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	var issues []IssueView
+	var missing []string
+	source := ""
+	if *fixVersion != "" {
+		source = "fixVersion " + *fixVersion
+		jql := fmt.Sprintf("fixVersion = %q", *fixVersion)
+		if len(projects) > 0 {
+			jql = fmt.Sprintf("project in (%s) AND %s", strings.Join(projects, ", "), jql)
+		}
+		found, err := searchIssues(cfg, jql+" ORDER BY key ASC", *limit)
+		if err != nil {
+			return err
+		}
+		if found.HasMore {
+			fmt.Fprintf(os.Stderr, "warning: more than %d issues match; raise --limit to include them\n", *limit)
+		}
+		issues = issuesToViews(found.Issues, cfg.Server)
+	} else {
+		source = "git " + *gitRange
+		commits, err := gitCommits(*gitRange)
+		if err != nil {
+			return err
+		}
+		if len(projects) == 0 {
+			if projects, err = getProjectKeys(cfg); err != nil {
+				return err
+			}
+		}
+		scanned, err := scanGitIssues(cfg, *gitRange, commits, projects)
+		if err != nil {
+			return err
+		}
+		for _, issue := range scanned.Issues {
+			issues = append(issues, issue.IssueView)
+		}
+		for _, m := range scanned.Missing {
+			missing = append(missing, m.Key)
+		}
+	}
+
+	notes := buildReleaseNotes(firstNonEmpty(*title, *fixVersion, *gitRange), source, issues, *groupBy == "type")
+	notes.Missing = missing
+
+	switch {
+	case tmpl != nil:
+		return tmpl.Execute(os.Stdout, notes)
+	case *format == "json":
+		return printJSON(notes)
+	default:
+		writeReleaseMarkdown(os.Stdout, notes)
+		return nil
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Release notes helpers
+// ---------------------------------------------------------------------------
+
+// buildReleaseNotes groups issues under headings derived from their type, or
+// into a single "Changes" group when byType is false.
+func buildReleaseNotes(title, source string, issues []IssueView, byType bool) ReleaseNotes {
+	notes := ReleaseNotes{Title: title, Source: source, Count: len(issues), Groups: []ReleaseGroup{}}
+	if len(issues) == 0 {
+		return notes
+	}
+	if !byType {
+		notes.Groups = append(notes.Groups, ReleaseGroup{Name: "Changes", Issues: issues})
+		return notes
+	}
+
+	byGroup := map[string][]IssueView{}
+	for _, issue := range issues {
+		name := releaseGroupName(issue.Type)
+		byGroup[name] = append(byGroup[name], issue)
+	}
+	names := sortedKeys(byGroup)
+	sort.SliceStable(names, func(i, j int) bool {
+		return releaseGroupRank(names[i]) < releaseGroupRank(names[j])
+	})
+	for _, name := range names {
+		notes.Groups = append(notes.Groups, ReleaseGroup{Name: name, Issues: byGroup[name]})
+	}
+	return notes
+}
+
+// releaseGroupName maps an issue type to a release notes heading.
+func releaseGroupName(issueType string) string {
+	switch strings.ToLower(issueType) {
+	case "story", "feature", "new feature", "improvement", "epic":
+		return "Features"
+	case "bug", "defect":
+		return "Bug fixes"
+	case "task", "sub-task", "subtask":
+		return "Tasks"
+	case "":
+		return "Other"
+	}
+	return issueType
+}
+
+func releaseGroupRank(name string) int {
+	for i, n := range releaseGroupOrder {
+		if n == name {
+			return i
+		}
+	}
+	return len(releaseGroupOrder)
+}
+
+func writeReleaseMarkdown(w io.Writer, notes ReleaseNotes) {
+	fmt.Fprintf(w, "# %s\n", notes.Title)
+	if notes.Count == 0 {
+		fmt.Fprintf(w, "\nNo issues found for %s.\n", notes.Source)
+	}
+	for _, g := range notes.Groups {
+		fmt.Fprintf(w, "\n## %s\n\n", g.Name)
+		for _, issue := range g.Issues {
+			fmt.Fprintf(w, "- [%s](%s) %s\n", issue.Key, issue.URL, issue.Summary)
+		}
+	}
+	if len(notes.Missing) > 0 {
+		fmt.Fprintf(w, "\n<!-- referenced but not found in Jira: %s -->\n", strings.Join(notes.Missing, ", "))
+	}
+}
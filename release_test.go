@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestBuildReleaseNotesGroupsByType(t *testing.T) {
+	issues := []IssueView{
+		{Key: "PROJ-3", Type: "Task", Summary: "Bump deps", URL: "https://x/browse/PROJ-3"},
+		{Key: "PROJ-1", Type: "Bug", Summary: "Fix crash", URL: "https://x/browse/PROJ-1"},
+		{Key: "PROJ-2", Type: "Story", Summary: "Dark mode", URL: "https://x/browse/PROJ-2"},
+		{Key: "PROJ-4", Type: "Spike", Summary: "Try caching", URL: "https://x/browse/PROJ-4"},
+	}
+	notes := buildReleaseNotes("2.3.0", "fixVersion 2.3.0", issues, true)
+
+	var names []string
+	for _, g := range notes.Groups {
+		names = append(names, g.Name)
+	}
+	if got := strings.Join(names, ","); got != "Features,Bug fixes,Tasks,Spike" {
+		t.Fatalf("unexpected group order %q", got)
+	}
+
+	var buf bytes.Buffer
+	writeReleaseMarkdown(&buf, notes)
+	out := buf.String()
+	if !containsAll(out, []string{"# 2.3.0", "## Features\n\n- [PROJ-2](https://x/browse/PROJ-2) Dark mode", "## Bug fixes"}) {
+		t.Fatalf("unexpected markdown:\n%s", out)
+	}
+
+	flat := buildReleaseNotes("2.3.0", "", issues, false)
+	if len(flat.Groups) != 1 || len(flat.Groups[0].Issues) != 4 {
+		t.Fatalf("expected one group with all issues, got %+v", flat.Groups)
+	}
+}
+
+func TestReleaseNotesTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.tmpl")
+	body := "{{.Title}}\n{{range .Groups}}{{.Name}}:{{range .Issues}} {{.Key}}{{end}}\n{{end}}"
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err := template.ParseFiles(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notes := buildReleaseNotes("v2", "", []IssueView{{Key: "PROJ-1", Type: "Bug"}, {Key: "PROJ-2", Type: "Bug"}}, true)
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, notes); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != "v2\nBug fixes: PROJ-1 PROJ-2\n" {
+		t.Fatalf("unexpected template output %q", got)
+	}
+}
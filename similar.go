@@ -0,0 +1,267 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+)
+
+// maxSimilarTerms caps the words sent to Jira's text search; summary words
+// come first.
+const maxSimilarTerms = 10
+
+// similarCandidatePool is the number of text search hits scored locally.
+const similarCandidatePool = 50
+
+// defaultDuplicateThreshold is the score at which "issues create
+// --check-duplicates" treats a candidate as a likely duplicate.
+const defaultDuplicateThreshold = 0.6
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type SimilarIssue struct {
+	IssueView
+	Score  float64  `json:"score"`
+	Shared []string `json:"shared_terms"`
+}
+
+type SimilarResult struct {
+	Summary    string         `json:"summary"`
+	JQL        string         `json:"jql"`
+	Count      int            `json:"count"`
+	Candidates []SimilarIssue `json:"candidates"`
+}
+
+// ---------------------------------------------------------------------------
+// Structured errors
+// ---------------------------------------------------------------------------
+
+// DuplicateIssuesError reports likely duplicates found by "issues create
+// --check-duplicates".
+type DuplicateIssuesError struct {
+	Summary    string         `json:"summary"`
+	Threshold  float64        `json:"threshold"`
+	Candidates []SimilarIssue `json:"candidates"`
+}
+
+func (e *DuplicateIssuesError) Error() string {
+	parts := make([]string, 0, len(e.Candidates))
+	for _, c := range e.Candidates {
+		parts = append(parts, fmt.Sprintf("%s (%.2f) %s", c.Key, c.Score, c.Summary))
+	}
+	return fmt.Sprintf("possible duplicates at or above %.2f: %s; pass --on-duplicate warn to create anyway",
+		e.Threshold, strings.Join(parts, "; "))
+}
+
+func (e *DuplicateIssuesError) Code() string { return "DUPLICATE_CANDIDATES" }
+
+func (e *DuplicateIssuesError) Details() any { return e }
+
+// ---------------------------------------------------------------------------
+// Similar command
+// ---------------------------------------------------------------------------
+
+func runIssuesSimilar(args []string) error {
+	fs := flag.NewFlagSet("issues similar", flag.ContinueOnError)
+	summary := fs.String("summary", "", "summary of the issue you are about to file (required)")
+	descriptionFile := fs.String("description-file", "", "file with the description, or - for stdin")
+	var projects stringList
+	fs.Var(&projects, "project", "only candidates in this project (repeatable)")
+	limit := fs.Int("limit", 10, "max candidates to return")
+	minScore := fs.Float64("min-score", 0, "only candidates scoring at least this (0-1)")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if strings.TrimSpace(*summary) == "" {
+		return errors.New("--summary is required")
+	}
+	if *limit <= 0 {
+		return errors.New("--limit must be greater than 0")
+	}
+
+	description, err := readDescriptionFile(*descriptionFile)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	result, err := findSimilarIssues(cfg, *summary, description, projects, *limit, *minScore)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		return printJSON(result)
+	}
+
+	if result.Count == 0 {
+		fmt.Println("No similar issues found.")
+		return nil
+	}
+	fmt.Printf("Similar issues (%d):\n", result.Count)
+	for _, c := range result.Candidates {
+		fmt.Printf("- %-12s  %.2f  [%s]  %s\n", c.Key, c.Score, c.Status, c.Summary)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Similar helpers
+// ---------------------------------------------------------------------------
+
+// findSimilarIssues narrows candidates with Jira's text search on the most
+// useful words, then ranks them locally by term overlap. Summary overlap
+// weighs most; description overlap breaks ties between similar summaries.
+func findSimilarIssues(cfg Config, summary, description string, projects []string, limit int, minScore float64) (SimilarResult, error) {
+	summaryTerms := uniqueStrings(searchTerms(summary))
+	allTerms := uniqueStrings(append(searchTerms(summary), searchTerms(description)...))
+	result := SimilarResult{Summary: summary, Candidates: []SimilarIssue{}}
+	if len(summaryTerms) == 0 {
+		return result, errors.New("the summary has no searchable words")
+	}
+
+	scope := ""
+	if len(projects) > 0 {
+		keys := make([]string, len(projects))
+		for i, p := range projects {
+			keys[i] = fmt.Sprintf("%q", strings.ToUpper(p))
+		}
+		scope = fmt.Sprintf("project in (%s) AND ", strings.Join(keys, ", "))
+	}
+	clauses := func(field, op string, words []string) string {
+		parts := make([]string, len(words))
+		for i, w := range words {
+			parts[i] = fmt.Sprintf("%s ~ %q", field, w)
+		}
+		return "(" + strings.Join(parts, " "+op+" ") + ")"
+	}
+	// Issues whose summary has every summary word are the likeliest
+	// duplicates, so they are fetched first whatever their age. The broader
+	// search on any word fills the pool; neither is ordered by recency, so
+	// an old duplicate in a busy project is not cut off.
+	strict := scope + clauses("summary", "AND", summaryTerms[:minInt(len(summaryTerms), maxSimilarTerms)])
+	result.JQL = scope + clauses("text", "OR", allTerms[:minInt(len(allTerms), maxSimilarTerms)])
+
+	var pool []JiraIssue
+	seen := map[string]bool{}
+	for _, jql := range []string{strict, result.JQL} {
+		found, err := searchIssuesWithFields(cfg, jql, similarCandidatePool, issueSearchFields+",description")
+		if err != nil {
+			return result, err
+		}
+		for _, issue := range found.Issues {
+			if !seen[issue.Key] {
+				seen[issue.Key] = true
+				pool = append(pool, issue)
+			}
+		}
+	}
+
+	for _, issue := range pool {
+		candSummary := searchTerms(issue.Fields.Summary)
+		candAll := append(searchTerms(issue.Fields.Summary), searchTerms(adfToText(issue.Fields.Description))...)
+		score := 0.7*termSimilarity(summaryTerms, candSummary) + 0.3*termSimilarity(allTerms, candAll)
+		score = math.Round(score*1000) / 1000
+		if score <= 0 || score < minScore {
+			continue
+		}
+		result.Candidates = append(result.Candidates, SimilarIssue{
+			IssueView: issueToView(issue, cfg.Server),
+			Score:     score,
+			Shared:    sharedTerms(allTerms, candAll),
+		})
+	}
+	sort.SliceStable(result.Candidates, func(i, j int) bool {
+		return result.Candidates[i].Score > result.Candidates[j].Score
+	})
+	if len(result.Candidates) > limit {
+		result.Candidates = result.Candidates[:limit]
+	}
+	result.Count = len(result.Candidates)
+	return result, nil
+}
+
+// termSimilarity is the Dice coefficient of the two term sets: 1 for the
+// same words, 0 for none in common.
+func termSimilarity(a, b []string) float64 {
+	setA, setB := map[string]bool{}, map[string]bool{}
+	for _, t := range a {
+		setA[t] = true
+	}
+	for _, t := range b {
+		setB[t] = true
+	}
+	if len(setA) == 0 || len(setB) == 0 {
+		return 0
+	}
+	common := 0
+	for t := range setA {
+		if setB[t] {
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(setA)+len(setB))
+}
+
+func sharedTerms(query, candidate []string) []string {
+	have := map[string]bool{}
+	for _, t := range candidate {
+		have[t] = true
+	}
+	shared := []string{}
+	for _, t := range query {
+		if have[t] {
+			shared = append(shared, t)
+		}
+	}
+	return shared
+}
+
+// checkDuplicates looks for issues similar to the one about to be created in
+// its project. With onDuplicate "refuse" candidates at or above threshold
+// fail with a DuplicateIssuesError; with "warn" they are returned.
+func checkDuplicates(cfg Config, in IssueCreateInput, threshold float64, onDuplicate string) ([]SimilarIssue, error) {
+	project := strings.ToUpper(strings.TrimSpace(in.Project))
+	if project == "" && in.Parent != "" {
+		project, _ = splitIssueKey(strings.ToUpper(strings.TrimSpace(in.Parent)))
+	}
+	var projects []string
+	if project != "" {
+		projects = []string{project}
+	}
+
+	result, err := findSimilarIssues(cfg, in.Summary, in.Description, projects, 5, threshold)
+	if err != nil {
+		return nil, fmt.Errorf("duplicate check: %w", err)
+	}
+	if len(result.Candidates) > 0 && onDuplicate == "refuse" {
+		return nil, &DuplicateIssuesError{Summary: in.Summary, Threshold: threshold, Candidates: result.Candidates}
+	}
+	return result.Candidates, nil
+}
+
+// readDescriptionFile reads a description from path, or stdin for "-". An
+// empty path is an empty description.
+func readDescriptionFile(path string) (string, error) {
+	switch path {
+	case "":
+		return "", nil
+	case "-":
+		b, err := io.ReadAll(os.Stdin)
+		return string(b), err
+	}
+	b, err := os.ReadFile(path)
+	return string(b), err
+}
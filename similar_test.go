@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFindSimilarIssuesRanksByTermOverlap(t *testing.T) {
+	var jql string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		jql = r.URL.Query().Get("jql")
+		writeJSON(t, w, JiraSearchResponse{Total: 3, Issues: []JiraIssue{
+			{Key: "PROJ-3", Fields: JiraIssueFields{Summary: "Exporter is slow"}},
+			{Key: "PROJ-7", Fields: JiraIssueFields{Summary: "Memory leak in CSV exporter", Description: "Heap grows on every export."}},
+			{Key: "PROJ-9", Fields: JiraIssueFields{Summary: "Update onboarding docs"}},
+		}})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	result, err := findSimilarIssues(cfg, "Exporter leaks memory", "The heap grows until OOM.", []string{"proj"}, 10, 0)
+	if err != nil {
+		t.Fatalf("findSimilarIssues returned error: %v", err)
+	}
+	if !containsAll(jql, []string{`project in ("PROJ")`, `text ~ "exporter"`, `text ~ "leak"`, `text ~ "heap"`, " OR "}) {
+		t.Fatalf("unexpected JQL %q", jql)
+	}
+	var keys []string
+	for _, c := range result.Candidates {
+		keys = append(keys, c.Key)
+	}
+	if got := strings.Join(keys, ","); got != "PROJ-7,PROJ-3" {
+		t.Fatalf("unexpected candidates %q", got)
+	}
+	if got := strings.Join(result.Candidates[0].Shared, ","); got != "exporter,leak,memory,heap,grow" {
+		t.Fatalf("unexpected shared terms %q", got)
+	}
+}
+
+func TestCheckDuplicatesRefusesAboveThreshold(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, JiraSearchResponse{Total: 1, Issues: []JiraIssue{
+			{Key: "PROJ-7", Fields: JiraIssueFields{Summary: "Login button broken on Safari"}},
+		}})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	in := IssueCreateInput{Project: "PROJ", Summary: "Login button broken in Safari"}
+
+	_, err := checkDuplicates(cfg, in, 0.6, "refuse")
+	var dup *DuplicateIssuesError
+	if !errors.As(err, &dup) || dup.Code() != "DUPLICATE_CANDIDATES" || dup.Candidates[0].Key != "PROJ-7" {
+		t.Fatalf("expected a duplicate error for PROJ-7, got %v", err)
+	}
+
+	warned, err := checkDuplicates(cfg, in, 0.6, "warn")
+	if err != nil || len(warned) != 1 {
+		t.Fatalf("expected one warning candidate, got %v, %v", warned, err)
+	}
+}
+
+func TestCheckDuplicatesFindsOlderDuplicateOutsideBroadSearch(t *testing.T) {
+	var queries []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		jql := r.URL.Query().Get("jql")
+		queries = append(queries, jql)
+		if strings.Contains(jql, "summary ~") {
+			writeJSON(t, w, JiraSearchResponse{Total: 1, Issues: []JiraIssue{
+				{Key: "PROJ-12", Fields: JiraIssueFields{Summary: "Login button broken on Safari", Updated: "2025-01-10T09:00:00.000+0000"}},
+			}})
+			return
+		}
+		// The broad search fills its pool with recently updated issues that
+		// only share a word or two.
+		var issues []JiraIssue
+		for i := 0; i < similarCandidatePool; i++ {
+			issues = append(issues, JiraIssue{Key: fmt.Sprintf("PROJ-%d", 900+i), Fields: JiraIssueFields{Summary: "Login page copy"}})
+		}
+		writeJSON(t, w, JiraSearchResponse{Total: 400, Issues: issues, NextPageToken: "next"})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	in := IssueCreateInput{Project: "PROJ", Summary: "Login button broken in Safari"}
+
+	_, err := checkDuplicates(cfg, in, 0.6, "refuse")
+	var dup *DuplicateIssuesError
+	if !errors.As(err, &dup) || dup.Candidates[0].Key != "PROJ-12" {
+		t.Fatalf("expected a duplicate error for PROJ-12, got %v", err)
+	}
+	if want := `project in ("PROJ") AND (summary ~ "login" AND summary ~ "button" AND summary ~ "broken" AND summary ~ "safari")`; queries[0] != want {
+		t.Fatalf("unexpected first JQL %q", queries[0])
+	}
+	for _, q := range queries {
+		if strings.Contains(strings.ToUpper(q), "ORDER BY") {
+			t.Fatalf("expected no recency ordering, got %q", q)
+		}
+	}
+}
@@ -0,0 +1,608 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Jira API response types
+// ---------------------------------------------------------------------------
+
+type JiraField struct {
+	ID     string           `json:"id"`
+	Name   string           `json:"name"`
+	Custom bool             `json:"custom"`
+	Schema *JiraFieldSchema `json:"schema"`
+}
+
+type JiraFieldSchema struct {
+	Type   string `json:"type"`
+	Items  string `json:"items"`
+	System string `json:"system"`
+	Custom string `json:"custom"`
+}
+
+type JiraStatus struct {
+	ID             string              `json:"id"`
+	Name           string              `json:"name"`
+	StatusCategory *JiraStatusCategory `json:"statusCategory"`
+}
+
+type JiraStatusCategory struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+type JiraChangelogEntry struct {
+	ID      string              `json:"id"`
+	Author  *JiraUser           `json:"author"`
+	Created string              `json:"created"`
+	Items   []JiraChangelogItem `json:"items"`
+}
+
+type JiraChangelogItem struct {
+	Field      string `json:"field"`
+	FieldID    string `json:"fieldId"`
+	From       string `json:"from"`
+	FromString string `json:"fromString"`
+	To         string `json:"to"`
+	ToString   string `json:"toString"`
+}
+
+type jiraRawIssuesResponse struct {
+	Total  int            `json:"total"`
+	Issues []jiraRawIssue `json:"issues"`
+}
+
+// jiraSprintReportResponse is the part of the board's sprint report used to
+// find issues that were moved out of a sprint; the agile sprint issue API
+// only lists issues still in it.
+type jiraSprintReportResponse struct {
+	Contents struct {
+		PuntedIssues []struct {
+			Key string `json:"key"`
+		} `json:"puntedIssues"`
+	} `json:"contents"`
+}
+
+// jiraRawIssue keeps fields undecoded so custom fields (story points) can be
+// read by ID.
+type jiraRawIssue struct {
+	Key    string                     `json:"key"`
+	Fields map[string]json.RawMessage `json:"fields"`
+}
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type SprintReport struct {
+	Sprint      SprintView      `json:"sprint"`
+	Unit        string          `json:"unit"`
+	PointsField string          `json:"points_field,omitempty"`
+	Committed   ReportBucket    `json:"committed"`
+	Added       ReportBucket    `json:"added"`
+	Removed     ReportBucket    `json:"removed"`
+	Completed   ReportBucket    `json:"completed"`
+	CarriedOver ReportBucket    `json:"carried_over"`
+	Burndown    []BurndownPoint `json:"burndown"`
+	// Warnings name anything that makes the report incomplete, such as
+	// removed issues that could not be loaded.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ReportBucket counts issues in one report category. Value is the issue count
+// or the story point sum, depending on the report unit.
+type ReportBucket struct {
+	Count  int      `json:"count"`
+	Value  float64  `json:"value"`
+	Issues []string `json:"issues"`
+}
+
+type BurndownPoint struct {
+	Date      string  `json:"date"`
+	Remaining float64 `json:"remaining"`
+	Scope     float64 `json:"scope"`
+	Ideal     float64 `json:"ideal"`
+}
+
+// sprintIssueHistory is the per-issue input to buildSprintReport: current
+// values plus the changes needed to replay them back in time.
+type sprintIssueHistory struct {
+	Key      string
+	Created  time.Time
+	StatusID string
+	Points   float64
+	Sprint   []fieldChange
+	Status   []fieldChange
+	Estimate []fieldChange
+}
+
+type fieldChange struct {
+	At   time.Time
+	From string
+	To   string
+}
+
+// ---------------------------------------------------------------------------
+// Sprint report command
+// ---------------------------------------------------------------------------
+
+func runSprintsReport(args []string) error {
+	fs := flag.NewFlagSet("sprints report", flag.ContinueOnError)
+	pointsField := fs.String("points-field", "", "story point field ID or name (default: count issues)")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	markdown := fs.Bool("markdown", false, "print a Markdown report")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if *jsonOut && *markdown {
+		return errors.New("--json and --markdown are mutually exclusive")
+	}
+
+	sprintID, err := sprintIDArg(positional, "jiractl sprints report 42")
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	sprint, err := getSprint(cfg, sprintID)
+	if err != nil {
+		return err
+	}
+
+	fieldID := ""
+	if *pointsField != "" {
+		fieldID, err = resolveFieldID(cfg, *pointsField)
+		if err != nil {
+			return err
+		}
+	}
+
+	statuses, err := getStatuses(cfg)
+	if err != nil {
+		return err
+	}
+	done := make(map[string]bool, len(statuses))
+	for _, s := range statuses {
+		if s.StatusCategory != nil && s.StatusCategory.Key == "done" {
+			done[s.ID] = true
+		}
+	}
+
+	histories, warnings, err := getSprintIssueHistories(cfg, sprint, fieldID)
+	if err != nil {
+		return err
+	}
+
+	report, err := buildSprintReport(sprint, histories, done, time.Now())
+	if err != nil {
+		return err
+	}
+	report.PointsField = fieldID
+	report.Warnings = warnings
+	if fieldID != "" && report.Unit != "points" {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("%s has no values on the sprint's issues; counting issues instead", *pointsField))
+	}
+
+	if *jsonOut {
+		return printJSON(report)
+	}
+	for _, w := range report.Warnings {
+		fmt.Fprintln(os.Stderr, "warning:", w)
+	}
+	if *markdown {
+		printSprintReportMarkdown(report)
+		return nil
+	}
+
+	fmt.Printf("Sprint:       %s (%d, %s)\n", report.Sprint.Name, report.Sprint.ID, report.Sprint.State)
+	fmt.Printf("Dates:        %s .. %s\n", report.Sprint.Start, firstNonEmpty(report.Sprint.Completed, report.Sprint.End))
+	fmt.Printf("Unit:         %s\n", report.Unit)
+	fmt.Println()
+	for _, row := range sprintReportRows(report) {
+		fmt.Printf("%-13s %3d  %6s  %s\n", row.label+":", row.bucket.Count, formatPoints(row.bucket.Value), strings.Join(row.bucket.Issues, ", "))
+	}
+	if len(report.Burndown) > 0 {
+		fmt.Println()
+		fmt.Println("Burndown:")
+		for _, p := range report.Burndown {
+			fmt.Printf("  %s  remaining %6s  scope %6s  ideal %6s\n", p.Date, formatPoints(p.Remaining), formatPoints(p.Scope), formatPoints(p.Ideal))
+		}
+	}
+	return nil
+}
+
+type sprintReportRow struct {
+	label  string
+	bucket ReportBucket
+}
+
+func sprintReportRows(r SprintReport) []sprintReportRow {
+	return []sprintReportRow{
+		{"Committed", r.Committed},
+		{"Added", r.Added},
+		{"Removed", r.Removed},
+		{"Completed", r.Completed},
+		{"Carried over", r.CarriedOver},
+	}
+}
+
+func printSprintReportMarkdown(r SprintReport) {
+	fmt.Printf("# Sprint report: %s\n\n", r.Sprint.Name)
+	fmt.Printf("- **State:** %s\n", r.Sprint.State)
+	fmt.Printf("- **Dates:** %s to %s\n", r.Sprint.Start, firstNonEmpty(r.Sprint.Completed, r.Sprint.End))
+	if r.Sprint.Goal != "" {
+		fmt.Printf("- **Goal:** %s\n", r.Sprint.Goal)
+	}
+	fmt.Printf("- **Unit:** %s\n\n", r.Unit)
+
+	fmt.Println("| Category | Issues | Value | Keys |")
+	fmt.Println("|----------|-------:|------:|------|")
+	for _, row := range sprintReportRows(r) {
+		fmt.Printf("| %s | %d | %s | %s |\n", row.label, row.bucket.Count, formatPoints(row.bucket.Value), strings.Join(row.bucket.Issues, ", "))
+	}
+
+	if len(r.Burndown) > 0 {
+		fmt.Println()
+		fmt.Println("## Burndown")
+		fmt.Println()
+		fmt.Println("| Date | Remaining | Scope | Ideal |")
+		fmt.Println("|------|----------:|------:|------:|")
+		for _, p := range r.Burndown {
+			fmt.Printf("| %s | %s | %s | %s |\n", p.Date, formatPoints(p.Remaining), formatPoints(p.Scope), formatPoints(p.Ideal))
+		}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Jira API calls
+// ---------------------------------------------------------------------------
+
+func getFields(cfg Config) ([]JiraField, error) {
+	return cachedGet(cfg, "fields", "", func() ([]JiraField, error) {
+		var fields []JiraField
+		err := jiraDo(cfg, http.MethodGet, "/rest/api/3/field", nil, nil, &fields)
+		return fields, err
+	})
+}
+
+func getStatuses(cfg Config) ([]JiraStatus, error) {
+	return cachedGet(cfg, "statuses", "", func() ([]JiraStatus, error) {
+		var statuses []JiraStatus
+		err := jiraDo(cfg, http.MethodGet, "/rest/api/3/status", nil, nil, &statuses)
+		return statuses, err
+	})
+}
+
+func getChangelog(cfg Config, issueKey string) ([]JiraChangelogEntry, error) {
+	path := "/rest/api/3/issue/" + url.PathEscape(issueKey) + "/changelog"
+	entries, _, err := getPagedValues[JiraChangelogEntry](cfg, path, nil, math.MaxInt32)
+	return entries, err
+}
+
+// resolveFieldID accepts a field ID (customfield_10016) or a field name
+// ("Story Points") and returns the field ID.
+func resolveFieldID(cfg Config, nameOrID string) (string, error) {
+	fields, err := getFields(cfg)
+	if err != nil {
+		return "", err
+	}
+	query := strings.TrimSpace(nameOrID)
+	for _, f := range fields {
+		if f.ID == query {
+			return f.ID, nil
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, query) {
+			return f.ID, nil
+		}
+	}
+	return "", fmt.Errorf("no field matching %q", query)
+}
+
+// getSprintIssueHistories loads every issue in the sprint, and every issue
+// removed from it, together with its changelog. If the removed issues can't
+// be loaded the report goes ahead without them and a warning says so.
+func getSprintIssueHistories(cfg Config, sprint JiraSprint, pointsField string) ([]sprintIssueHistory, []string, error) {
+	fields := "status,created"
+	if pointsField != "" {
+		fields += "," + pointsField
+	}
+	path := fmt.Sprintf("/rest/agile/1.0/sprint/%d/issue", sprint.ID)
+
+	var raw []jiraRawIssue
+	for {
+		q := url.Values{}
+		q.Set("startAt", strconv.Itoa(len(raw)))
+		q.Set("maxResults", "100")
+		q.Set("fields", fields)
+
+		var page jiraRawIssuesResponse
+		if err := jiraDo(cfg, http.MethodGet, path, q, nil, &page); err != nil {
+			return nil, nil, err
+		}
+		raw = append(raw, page.Issues...)
+		if len(page.Issues) == 0 || len(raw) >= page.Total {
+			break
+		}
+	}
+
+	var warnings []string
+	removed, err := getRemovedSprintIssues(cfg, sprint, raw, fields)
+	if err != nil {
+		warnings = append(warnings, fmt.Sprintf("could not load issues removed from the sprint, so removed and added counts may be low: %v", err))
+	}
+	raw = append(raw, removed...)
+
+	histories := make([]sprintIssueHistory, 0, len(raw))
+	for _, issue := range raw {
+		h := sprintIssueHistory{Key: issue.Key}
+
+		var status JiraNameField
+		if err := json.Unmarshal(issue.Fields["status"], &status); err == nil {
+			h.StatusID = status.ID
+		}
+		var created string
+		if err := json.Unmarshal(issue.Fields["created"], &created); err == nil {
+			h.Created, _ = parseJiraTime(created)
+		}
+		if pointsField != "" {
+			h.Points = rawNumber(issue.Fields[pointsField])
+		}
+
+		changelog, err := getChangelog(cfg, issue.Key)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", issue.Key, err)
+		}
+		for _, entry := range changelog {
+			at, err := parseJiraTime(entry.Created)
+			if err != nil {
+				continue
+			}
+			for _, item := range entry.Items {
+				switch {
+				case strings.EqualFold(item.Field, "Sprint"):
+					h.Sprint = append(h.Sprint, fieldChange{At: at, From: item.From, To: item.To})
+				case item.FieldID == "status" || item.Field == "status":
+					h.Status = append(h.Status, fieldChange{At: at, From: item.From, To: item.To})
+				case pointsField != "" && item.FieldID == pointsField:
+					h.Estimate = append(h.Estimate, fieldChange{At: at, From: item.FromString, To: item.ToString})
+				}
+			}
+		}
+		histories = append(histories, h)
+	}
+	return histories, warnings, nil
+}
+
+// getRemovedSprintIssues loads the issues the board's sprint report lists as
+// removed from the sprint, skipping any already in current.
+func getRemovedSprintIssues(cfg Config, sprint JiraSprint, current []jiraRawIssue, fields string) ([]jiraRawIssue, error) {
+	if sprint.OriginBoardID == 0 {
+		return nil, errors.New("the sprint has no board to read its sprint report from")
+	}
+	var report jiraSprintReportResponse
+	q := url.Values{}
+	q.Set("rapidViewId", strconv.Itoa(sprint.OriginBoardID))
+	q.Set("sprintId", strconv.Itoa(sprint.ID))
+	if err := jiraDo(cfg, http.MethodGet, "/rest/greenhopper/1.0/rapid/charts/sprintreport", q, nil, &report); err != nil {
+		return nil, err
+	}
+
+	have := map[string]bool{}
+	for _, issue := range current {
+		have[issue.Key] = true
+	}
+	var keys []string
+	for _, p := range report.Contents.PuntedIssues {
+		if p.Key != "" && !have[p.Key] {
+			have[p.Key] = true
+			keys = append(keys, p.Key)
+		}
+	}
+
+	var removed []jiraRawIssue
+	for _, chunk := range chunkStrings(keys, 100) {
+		q := url.Values{}
+		q.Set("jql", keysJQL(chunk))
+		q.Set("maxResults", "100")
+		q.Set("fields", fields)
+		var page jiraRawIssuesResponse
+		if err := jiraDo(cfg, http.MethodGet, "/rest/api/3/search/jql", q, nil, &page); err != nil {
+			return removed, err
+		}
+		removed = append(removed, page.Issues...)
+	}
+	return removed, nil
+}
+
+// ---------------------------------------------------------------------------
+// Report computation
+// ---------------------------------------------------------------------------
+
+// buildSprintReport replays each issue's sprint, status and estimate history
+// to classify scope changes and compute a daily burndown. Completion is
+// judged by status category at sprint end (or now, for active sprints).
+func buildSprintReport(sprint JiraSprint, issues []sprintIssueHistory, doneStatus map[string]bool, now time.Time) (SprintReport, error) {
+	report := SprintReport{Sprint: sprintToView(sprint), Unit: "issues"}
+	for _, h := range issues {
+		if h.Points != 0 || len(h.Estimate) > 0 {
+			report.Unit = "points"
+			break
+		}
+	}
+
+	if sprint.StartDate == "" {
+		return report, fmt.Errorf("sprint %d has not started", sprint.ID)
+	}
+	start, err := parseJiraTime(sprint.StartDate)
+	if err != nil {
+		return report, err
+	}
+	end := now
+	if sprint.CompleteDate != "" {
+		if t, err := parseJiraTime(sprint.CompleteDate); err == nil {
+			end = t
+		}
+	} else if sprint.EndDate != "" {
+		if t, err := parseJiraTime(sprint.EndDate); err == nil && t.Before(now) {
+			end = t
+		}
+	}
+	if end.Before(start) {
+		end = start
+	}
+
+	sprintID := strconv.Itoa(sprint.ID)
+	member := func(h sprintIssueHistory, at time.Time) bool {
+		if !h.Created.IsZero() && at.Before(h.Created) {
+			return false
+		}
+		current := sprintID
+		if n := len(h.Sprint); n > 0 {
+			current = h.Sprint[n-1].To
+		}
+		return containsID(valueAt(h.Sprint, current, at), sprintID)
+	}
+	completed := func(h sprintIssueHistory, at time.Time) bool {
+		return doneStatus[valueAt(h.Status, h.StatusID, at)]
+	}
+	weight := func(h sprintIssueHistory, at time.Time) float64 {
+		if report.Unit == "issues" {
+			return 1
+		}
+		current := strconv.FormatFloat(h.Points, 'f', -1, 64)
+		v, _ := strconv.ParseFloat(valueAt(h.Estimate, current, at), 64)
+		return v
+	}
+
+	add := func(b *ReportBucket, h sprintIssueHistory, at time.Time) {
+		b.Count++
+		b.Value += weight(h, at)
+		b.Issues = append(b.Issues, h.Key)
+	}
+
+	for _, h := range issues {
+		inAtStart := member(h, start)
+		inAtEnd := member(h, end)
+		joined := false
+		for _, c := range h.Sprint {
+			if c.At.After(start) && !c.At.After(end) && containsID(c.To, sprintID) && !containsID(c.From, sprintID) {
+				joined = true
+			}
+		}
+		if !inAtStart && !h.Created.IsZero() && h.Created.After(start) && !h.Created.After(end) && len(h.Sprint) == 0 {
+			// Created directly into the running sprint.
+			joined = true
+		}
+
+		if inAtStart {
+			add(&report.Committed, h, start)
+		} else if joined {
+			add(&report.Added, h, end)
+		}
+		switch {
+		case (inAtStart || joined) && !inAtEnd:
+			add(&report.Removed, h, end)
+		case inAtEnd && completed(h, end):
+			add(&report.Completed, h, end)
+		case inAtEnd:
+			add(&report.CarriedOver, h, end)
+		}
+	}
+
+	days := int(dayStart(end).Sub(dayStart(start)).Hours()/24) + 1
+	for d := 0; d < days; d++ {
+		day := dayStart(start).AddDate(0, 0, d)
+		at := day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		if at.After(end) {
+			at = end
+		}
+		var remaining, scope float64
+		for _, h := range issues {
+			if !member(h, at) {
+				continue
+			}
+			w := weight(h, at)
+			scope += w
+			if !completed(h, at) {
+				remaining += w
+			}
+		}
+		ideal := report.Committed.Value
+		if days > 1 {
+			ideal = report.Committed.Value * float64(days-1-d) / float64(days-1)
+		}
+		report.Burndown = append(report.Burndown, BurndownPoint{
+			Date:      day.Format("2006-01-02"),
+			Remaining: remaining,
+			Scope:     scope,
+			Ideal:     math.Round(ideal*100) / 100,
+		})
+	}
+
+	for _, b := range []*ReportBucket{&report.Committed, &report.Added, &report.Removed, &report.Completed, &report.CarriedOver} {
+		if b.Issues == nil {
+			b.Issues = []string{}
+		}
+		sort.Strings(b.Issues)
+	}
+	return report, nil
+}
+
+// valueAt returns a field's value at time at, given its current value and
+// its change history in chronological order.
+func valueAt(changes []fieldChange, current string, at time.Time) string {
+	for _, c := range changes {
+		if c.At.After(at) {
+			return c.From
+		}
+	}
+	return current
+}
+
+// containsID reports whether a comma-separated ID list (as used by the
+// Sprint field changelog) contains id.
+func containsID(list, id string) bool {
+	for _, part := range strings.Split(list, ",") {
+		if strings.TrimSpace(part) == id {
+			return true
+		}
+	}
+	return false
+}
+
+func dayStart(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func rawNumber(raw json.RawMessage) float64 {
+	var v float64
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return 0
+	}
+	return v
+}
+
+func formatPoints(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
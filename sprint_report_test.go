@@ -0,0 +1,149 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildSprintReportClassifiesScope(t *testing.T) {
+	sprint := JiraSprint{
+		ID:           12,
+		Name:         "Sprint 12",
+		State:        "closed",
+		StartDate:    "2026-03-02T09:00:00.000Z",
+		EndDate:      "2026-03-06T17:00:00.000Z",
+		CompleteDate: "2026-03-06T17:00:00.000Z",
+	}
+	at := func(s string) time.Time {
+		ts, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ts
+	}
+	done := map[string]bool{"3": true}
+
+	issues := []sprintIssueHistory{
+		// Committed and finished on day 2.
+		{
+			Key: "PROJ-1", StatusID: "3",
+			Sprint: []fieldChange{{At: at("2026-03-01T10:00:00Z"), From: "", To: "12"}},
+			Status: []fieldChange{{At: at("2026-03-03T12:00:00Z"), From: "1", To: "3"}},
+		},
+		// Committed, never finished: carried over into sprint 13.
+		{
+			Key: "PROJ-2", StatusID: "1",
+			Sprint: []fieldChange{
+				{At: at("2026-03-01T10:00:00Z"), From: "", To: "12"},
+				{At: at("2026-03-06T17:00:01Z"), From: "12", To: "12, 13"},
+			},
+		},
+		// Added mid-sprint, not finished.
+		{
+			Key: "PROJ-3", StatusID: "1",
+			Sprint: []fieldChange{{At: at("2026-03-04T09:00:00Z"), From: "", To: "12"}},
+		},
+		// Committed, then pulled out mid-sprint.
+		{
+			Key: "PROJ-4", StatusID: "1",
+			Sprint: []fieldChange{
+				{At: at("2026-03-01T10:00:00Z"), From: "", To: "12"},
+				{At: at("2026-03-05T09:00:00Z"), From: "12", To: ""},
+			},
+		},
+	}
+
+	report, err := buildSprintReport(sprint, issues, done, at("2026-03-20T00:00:00Z"))
+	if err != nil {
+		t.Fatalf("buildSprintReport returned error: %v", err)
+	}
+
+	check := func(name string, b ReportBucket, want string) {
+		t.Helper()
+		if got := strings.Join(b.Issues, ","); got != want {
+			t.Fatalf("%s: expected %q, got %q", name, want, got)
+		}
+	}
+	check("committed", report.Committed, "PROJ-1,PROJ-2,PROJ-4")
+	check("added", report.Added, "PROJ-3")
+	check("removed", report.Removed, "PROJ-4")
+	check("completed", report.Completed, "PROJ-1")
+	check("carried over", report.CarriedOver, "PROJ-2,PROJ-3")
+
+	if got := len(report.Burndown); got != 5 {
+		t.Fatalf("expected 5 burndown days, got %d", got)
+	}
+	first, last := report.Burndown[0], report.Burndown[len(report.Burndown)-1]
+	if first.Remaining != 3 || first.Ideal != 3 {
+		t.Fatalf("unexpected first burndown point %+v", first)
+	}
+	if last.Remaining != 2 || last.Scope != 3 || last.Ideal != 0 {
+		t.Fatalf("unexpected last burndown point %+v", last)
+	}
+}
+
+func TestGetSprintIssueHistoriesIncludesRemovedIssues(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/agile/1.0/sprint/5/issue", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"total":1,"issues":[{"key":"PROJ-1","fields":{"status":{"id":"1"}}}]}`))
+	})
+	mux.HandleFunc("/rest/greenhopper/1.0/rapid/charts/sprintreport", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("rapidViewId") != "3" || r.URL.Query().Get("sprintId") != "5" {
+			t.Fatalf("unexpected sprint report query %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"contents":{"puntedIssues":[{"key":"PROJ-4"},{"key":"PROJ-1"}]}}`))
+	})
+	mux.HandleFunc("/rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("jql"); got != "key in (PROJ-4)" {
+			t.Fatalf("unexpected JQL %q", got)
+		}
+		w.Write([]byte(`{"issues":[{"key":"PROJ-4","fields":{"status":{"id":"1"}}}]}`))
+	})
+	mux.HandleFunc("/rest/api/3/issue/PROJ-1/changelog", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"values":[],"isLast":true}`))
+	})
+	mux.HandleFunc("/rest/api/3/issue/PROJ-4/changelog", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"values":[{"created":"2026-03-03T10:00:00.000+0000","items":[{"field":"Sprint","from":"5","to":""}]}],"isLast":true}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	histories, warnings, err := getSprintIssueHistories(cfg, JiraSprint{ID: 5, OriginBoardID: 3}, "")
+	if err != nil {
+		t.Fatalf("getSprintIssueHistories returned error: %v", err)
+	}
+	if len(histories) != 2 || histories[1].Key != "PROJ-4" || len(histories[1].Sprint) != 1 {
+		t.Fatalf("expected the removed issue with its sprint history, got %+v", histories)
+	}
+	if len(warnings) != 0 {
+		t.Fatalf("expected no warnings, got %q", warnings)
+	}
+}
+
+func TestGetSprintIssueHistoriesWarnsWhenRemovedIssuesAreUnavailable(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/agile/1.0/sprint/5/issue", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"total":1,"issues":[{"key":"PROJ-1","fields":{"status":{"id":"1"}}}]}`))
+	})
+	mux.HandleFunc("/rest/greenhopper/1.0/rapid/charts/sprintreport", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "gone", http.StatusNotFound)
+	})
+	mux.HandleFunc("/rest/api/3/issue/PROJ-1/changelog", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"values":[],"isLast":true}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	histories, warnings, err := getSprintIssueHistories(cfg, JiraSprint{ID: 5, OriginBoardID: 3}, "")
+	if err != nil {
+		t.Fatalf("getSprintIssueHistories returned error: %v", err)
+	}
+	if len(histories) != 1 || len(warnings) != 1 || !strings.Contains(warnings[0], "removed from the sprint") {
+		t.Fatalf("expected the report to go ahead with a warning, got %+v, %q", histories, warnings)
+	}
+}
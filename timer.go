@@ -0,0 +1,387 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// defaultTimerRounding is used when neither --round nor JIRACTL_TIMER_ROUND
+// is set.
+const defaultTimerRounding = "15m"
+
+// ---------------------------------------------------------------------------
+// Timer state
+// ---------------------------------------------------------------------------
+
+// TimerState is the running timer persisted in the config directory.
+type TimerState struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary,omitempty"`
+	Started string `json:"started"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type TimerView struct {
+	Running        bool   `json:"running"`
+	Key            string `json:"key,omitempty"`
+	Summary        string `json:"summary,omitempty"`
+	Started        string `json:"started,omitempty"`
+	ElapsedSeconds int    `json:"elapsed_seconds,omitempty"`
+	Elapsed        string `json:"elapsed,omitempty"`
+	URL            string `json:"url,omitempty"`
+}
+
+type TimerStopResult struct {
+	WorklogResult
+	ElapsedSeconds int `json:"elapsed_seconds"`
+}
+
+type TimerStartResult struct {
+	Timer   TimerView        `json:"timer"`
+	Stopped *TimerStopResult `json:"stopped,omitempty"`
+}
+
+// ---------------------------------------------------------------------------
+// Help functions
+// ---------------------------------------------------------------------------
+
+func printTimerHelp() {
+	fmt.Println("jiractl timer commands:")
+	fmt.Println("  timer start    ISSUE-KEY [--comment TEXT] [--auto-stop] [--round 15m] [--json]")
+	fmt.Println("  timer status   [--json]")
+	fmt.Println("  timer stop     [--comment TEXT] [--round 15m] [--dry-run] [--json]")
+	fmt.Println("  timer discard  [--json]")
+}
+
+// ---------------------------------------------------------------------------
+// Timer commands
+// ---------------------------------------------------------------------------
+
+func runTimer(args []string) error {
+	if len(args) == 0 {
+		printTimerHelp()
+		return nil
+	}
+
+	switch args[0] {
+	case "start":
+		return runTimerStart(args[1:])
+	case "status":
+		return runTimerStatus(args[1:])
+	case "stop":
+		return runTimerStop(args[1:])
+	case "discard":
+		return runTimerDiscard(args[1:])
+	case "help", "--help", "-h":
+		printTimerHelp()
+		return nil
+	default:
+		printTimerHelp()
+		return fmt.Errorf("unknown timer command %q", args[0])
+	}
+}
+
+func runTimerStart(args []string) error {
+	fs := flag.NewFlagSet("timer start", flag.ContinueOnError)
+	comment := fs.String("comment", "", "default worklog comment for this timer")
+	autoStop := fs.Bool("auto-stop", false, "stop and log a running timer instead of failing")
+	round := fs.String("round", "", "rounding for an auto-stopped timer (default $JIRACTL_TIMER_ROUND or 15m)")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("issue key is required (e.g. jiractl timer start PROJ-123)")
+	}
+	issueKey := strings.ToUpper(positional[0])
+
+	granularity, err := timerRounding(*round)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	running, err := loadTimer()
+	if err != nil {
+		return err
+	}
+	if running != nil && !*autoStop {
+		return fmt.Errorf("timer already running for %s since %s; run: jiractl timer stop (or pass --auto-stop)", running.Key, running.Started)
+	}
+
+	// Check the new issue before --auto-stop logs the running timer, so a
+	// mistyped key leaves the running timer untouched.
+	issue, err := getIssue(cfg, issueKey)
+	if err != nil {
+		return err
+	}
+
+	var stopped *TimerStopResult
+	if running != nil {
+		result, err := stopTimer(cfg, *running, "", granularity, time.Now(), false)
+		if err != nil {
+			return fmt.Errorf("failed to stop running timer for %s: %w", running.Key, err)
+		}
+		stopped = &result
+	}
+
+	state := TimerState{
+		Key:     issueKey,
+		Summary: issue.Fields.Summary,
+		Started: time.Now().Format(time.RFC3339),
+		Comment: *comment,
+	}
+	if err := saveTimer(state); err != nil {
+		return err
+	}
+
+	out := TimerStartResult{Timer: timerToView(&state, time.Now(), cfg.Server), Stopped: stopped}
+	if *jsonOut {
+		return printJSON(out)
+	}
+
+	if stopped != nil {
+		fmt.Printf("Stopped %s: logged %s\n", stopped.Key, stopped.TimeSpent)
+	}
+	fmt.Printf("Timer started for %s: %s\n", state.Key, state.Summary)
+	return nil
+}
+
+func runTimerStatus(args []string) error {
+	fs := flag.NewFlagSet("timer status", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	state, err := loadTimer()
+	if err != nil {
+		return err
+	}
+
+	server := ""
+	if cfg, err := loadAuthConfig(); err == nil {
+		server = cfg.Server
+	}
+	view := timerToView(state, time.Now(), server)
+
+	if *jsonOut {
+		return printJSON(view)
+	}
+
+	if !view.Running {
+		fmt.Println("No timer running.")
+		return nil
+	}
+	fmt.Printf("Timer running for %s (%s): %s since %s\n", view.Key, view.Summary, view.Elapsed, view.Started)
+	return nil
+}
+
+func runTimerStop(args []string) error {
+	fs := flag.NewFlagSet("timer stop", flag.ContinueOnError)
+	comment := fs.String("comment", "", "worklog comment (overrides the one given at start)")
+	round := fs.String("round", "", "round to this granularity (default $JIRACTL_TIMER_ROUND or 15m)")
+	dryRun := fs.Bool("dry-run", false, "show the worklog without posting it or stopping the timer")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	granularity, err := timerRounding(*round)
+	if err != nil {
+		return err
+	}
+
+	state, err := loadTimer()
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return errors.New("no timer running; start one with: jiractl timer start PROJ-123")
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	result, err := stopTimer(cfg, *state, *comment, granularity, time.Now(), *dryRun)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		return printJSON(result)
+	}
+	if *dryRun {
+		fmt.Printf("Would log %s on %s (elapsed %s)\n", result.TimeSpent, result.Key, formatWorkDuration(result.ElapsedSeconds))
+		return nil
+	}
+	fmt.Printf("Logged %s on %s (elapsed %s)\n", result.TimeSpent, result.Key, formatWorkDuration(result.ElapsedSeconds))
+	return nil
+}
+
+func runTimerDiscard(args []string) error {
+	fs := flag.NewFlagSet("timer discard", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	state, err := loadTimer()
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		return errors.New("no timer running")
+	}
+	if err := clearTimer(); err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		return printJSON(map[string]any{"key": state.Key, "discarded": true})
+	}
+	fmt.Printf("Timer for %s discarded.\n", state.Key)
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Timer helpers
+// ---------------------------------------------------------------------------
+
+// stopTimer rounds the elapsed time, posts the worklog and clears the
+// timer. With dryRun nothing is posted and the timer keeps running.
+func stopTimer(cfg Config, state TimerState, comment string, granularity time.Duration, now time.Time, dryRun bool) (TimerStopResult, error) {
+	started, err := time.Parse(time.RFC3339, state.Started)
+	if err != nil {
+		return TimerStopResult{}, fmt.Errorf("corrupt timer state: %w", err)
+	}
+	elapsed := now.Sub(started)
+	seconds := int(roundDuration(elapsed, granularity).Seconds())
+
+	worklog, err := logWork(cfg, state.Key, started, seconds, firstNonEmpty(comment, state.Comment), dryRun)
+	if err != nil {
+		return TimerStopResult{}, err
+	}
+	if !dryRun {
+		if err := clearTimer(); err != nil {
+			return TimerStopResult{}, err
+		}
+	}
+	return TimerStopResult{WorklogResult: worklog, ElapsedSeconds: int(elapsed.Seconds())}, nil
+}
+
+// roundDuration rounds d to the nearest multiple of granularity, but never
+// below one granule (or one minute without rounding), since Jira rejects
+// empty worklogs.
+func roundDuration(d, granularity time.Duration) time.Duration {
+	if granularity < time.Minute {
+		granularity = time.Minute
+	}
+	rounded := d.Round(granularity)
+	if rounded < granularity {
+		rounded = granularity
+	}
+	return rounded
+}
+
+// timerRounding resolves the rounding granularity: flag > env > default.
+func timerRounding(flagValue string) (time.Duration, error) {
+	v := firstNonEmpty(flagValue, os.Getenv("JIRACTL_TIMER_ROUND"), defaultTimerRounding)
+	if v == "0" || strings.EqualFold(v, "none") {
+		return time.Minute, nil
+	}
+	seconds, err := parseWorkDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid rounding %q: %w", v, err)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+func timerToView(state *TimerState, now time.Time, server string) TimerView {
+	if state == nil {
+		return TimerView{Running: false}
+	}
+	view := TimerView{
+		Running: true,
+		Key:     state.Key,
+		Summary: state.Summary,
+		Started: state.Started,
+	}
+	if started, err := time.Parse(time.RFC3339, state.Started); err == nil {
+		view.Started = started.Format("2006-01-02 15:04")
+		view.ElapsedSeconds = int(now.Sub(started).Seconds())
+		view.Elapsed = formatWorkDuration(view.ElapsedSeconds)
+	}
+	if server != "" {
+		view.URL = server + "/browse/" + state.Key
+	}
+	return view
+}
+
+func timerPath() (string, error) {
+	d, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d, "timer.json"), nil
+}
+
+// loadTimer returns the running timer, or nil when none is running.
+func loadTimer() (*TimerState, error) {
+	path, err := timerPath()
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var state TimerState
+	if err := json.Unmarshal(b, &state); err != nil {
+		return nil, fmt.Errorf("corrupt timer state in %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+func saveTimer(state TimerState) error {
+	path, err := timerPath()
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o600)
+}
+
+func clearTimer() error {
+	path, err := timerPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
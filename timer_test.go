@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRoundDuration(t *testing.T) {
+	cases := []struct {
+		elapsed time.Duration
+		want    time.Duration
+	}{
+		{3 * time.Minute, 15 * time.Minute},
+		{22 * time.Minute, 15 * time.Minute},
+		{23 * time.Minute, 30 * time.Minute},
+		{95 * time.Minute, 90 * time.Minute},
+	}
+	for _, tc := range cases {
+		if got := roundDuration(tc.elapsed, 15*time.Minute); got != tc.want {
+			t.Fatalf("roundDuration(%s) = %s, want %s", tc.elapsed, got, tc.want)
+		}
+	}
+}
+
+func TestStopTimerPostsRoundedWorklogAndClearsState(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	var posted JiraWorklogRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/PROJ-7/worklog", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("expected POST, got %s", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&posted); err != nil {
+			t.Fatalf("failed to decode worklog: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		writeJSON(t, w, JiraWorklog{ID: "10001"})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	started := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	state := TimerState{Key: "PROJ-7", Started: started.Format(time.RFC3339), Comment: "pairing"}
+	if err := saveTimer(state); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	result, err := stopTimer(cfg, state, "", 15*time.Minute, started.Add(52*time.Minute), false)
+	if err != nil {
+		t.Fatalf("stopTimer returned error: %v", err)
+	}
+
+	if posted.TimeSpentSeconds != 45*60 {
+		t.Fatalf("expected 45m worklog, got %d seconds", posted.TimeSpentSeconds)
+	}
+	if result.ID != "10001" || result.Comment != "pairing" {
+		t.Fatalf("unexpected stop result %+v", result)
+	}
+	if running, err := loadTimer(); err != nil || running != nil {
+		t.Fatalf("expected timer to be cleared, got %+v (err %v)", running, err)
+	}
+}
+
+func TestTimerStartAutoStopKeepsRunningTimerWhenNewKeyIsInvalid(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/PROJ-99", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errorMessages":["Issue does not exist"]}`, http.StatusNotFound)
+	})
+	mux.HandleFunc("/rest/api/3/issue/PROJ-7/worklog", func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("running timer must not be logged when the new key is invalid")
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	t.Setenv("JIRACTL_SERVER", ts.URL)
+	t.Setenv("JIRACTL_EMAIL", "user@example.com")
+	t.Setenv("JIRACTL_API_TOKEN", "token")
+
+	state := TimerState{Key: "PROJ-7", Started: time.Now().Add(-time.Hour).Format(time.RFC3339)}
+	if err := saveTimer(state); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runTimerStart([]string{"PROJ-99", "--auto-stop"}); err == nil {
+		t.Fatal("expected an error for a missing issue")
+	}
+	if running, err := loadTimer(); err != nil || running == nil || running.Key != "PROJ-7" {
+		t.Fatalf("expected PROJ-7 timer to keep running, got %+v (err %v)", running, err)
+	}
+}
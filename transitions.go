@@ -0,0 +1,278 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ---------------------------------------------------------------------------
+// Jira API response types
+// ---------------------------------------------------------------------------
+
+// JiraFieldMeta describes a field on a transition or create screen.
+type JiraFieldMeta struct {
+	Required        bool               `json:"required"`
+	Name            string             `json:"name"`
+	Key             string             `json:"key"`
+	FieldID         string             `json:"fieldId"`
+	Schema          JiraFieldSchema    `json:"schema"`
+	HasDefaultValue bool               `json:"hasDefaultValue"`
+	AllowedValues   []JiraAllowedValue `json:"allowedValues"`
+}
+
+// JiraAllowedValue is an option, resolution, version, component etc. Options
+// carry "value"; most other types carry "name".
+type JiraAllowedValue struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type FieldMetaView struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	Type          string   `json:"type"`
+	Required      bool     `json:"required"`
+	HasDefault    bool     `json:"has_default,omitempty"`
+	AllowedValues []string `json:"allowed_values,omitempty"`
+}
+
+// TransitionOptions are the optional screen fields and comment sent with a
+// transition.
+type TransitionOptions struct {
+	Resolution string
+	// Fields maps a field ID or name to its raw command-line value.
+	Fields  map[string]string
+	Comment string
+	// MatchTargets also matches --status against the status each
+	// transition leads to, not only transition names.
+	MatchTargets bool
+}
+
+// ---------------------------------------------------------------------------
+// Structured errors
+// ---------------------------------------------------------------------------
+
+// codedError is an error with a machine-readable code and details, printed
+// as structured JSON by printJSONError.
+type codedError interface {
+	error
+	Code() string
+	Details() any
+}
+
+// MissingFieldsError reports required transition screen fields that were not
+// supplied.
+type MissingFieldsError struct {
+	Issue      string          `json:"issue"`
+	Transition string          `json:"transition"`
+	Fields     []FieldMetaView `json:"fields"`
+}
+
+func (e *MissingFieldsError) Error() string {
+	parts := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		p := fmt.Sprintf("%s (%s)", f.Name, f.ID)
+		if len(f.AllowedValues) > 0 {
+			p = fmt.Sprintf("%s (%s; one of: %s)", f.Name, f.ID, strings.Join(f.AllowedValues, ", "))
+		}
+		parts = append(parts, p)
+	}
+	return fmt.Sprintf("transition %q on %s requires fields: %s; pass --resolution or --field NAME=VALUE",
+		e.Transition, e.Issue, strings.Join(parts, "; "))
+}
+
+func (e *MissingFieldsError) Code() string { return "MISSING_FIELDS" }
+
+func (e *MissingFieldsError) Details() any { return e }
+
+// ---------------------------------------------------------------------------
+// Transition field helpers
+// ---------------------------------------------------------------------------
+
+// buildTransitionFields converts the options into the fields and update
+// blocks of a transition request, using the transition's screen metadata to
+// shape each value. It fails with a MissingFieldsError when required fields
+// without defaults are left out.
+func buildTransitionFields(cfg Config, issueKey string, t JiraTransition, opts TransitionOptions) (map[string]any, map[string][]map[string]any, error) {
+	raw := map[string]string{}
+	for k, v := range opts.Fields {
+		raw[k] = v
+	}
+	if opts.Resolution != "" {
+		raw["resolution"] = opts.Resolution
+	}
+
+	fields := map[string]any{}
+	for name, value := range raw {
+		id, meta, ok := findFieldMeta(t.Fields, name)
+		if !ok {
+			if len(t.Fields) == 0 {
+				return nil, nil, fmt.Errorf("transition %q has no screen; it does not accept field %q", t.Name, name)
+			}
+			return nil, nil, fmt.Errorf("field %q is not on the %q transition screen; available: %s", name, t.Name, strings.Join(fieldMetaNames(t.Fields), ", "))
+		}
+		v, err := fieldValue(cfg, id, meta, value)
+		if err != nil {
+			return nil, nil, fmt.Errorf("field %s: %w", meta.Name, err)
+		}
+		fields[id] = v
+	}
+
+	var missing []FieldMetaView
+	for _, id := range sortedKeys(t.Fields) {
+		meta := t.Fields[id]
+		if meta.Required && !meta.HasDefaultValue && fields[id] == nil {
+			missing = append(missing, fieldMetaToView(id, meta))
+		}
+	}
+	if len(missing) > 0 {
+		return nil, nil, &MissingFieldsError{Issue: issueKey, Transition: t.Name, Fields: missing}
+	}
+
+	var update map[string][]map[string]any
+	if opts.Comment != "" {
+		update = map[string][]map[string]any{
+			"comment": {{"add": map[string]any{"body": textToADF(opts.Comment)}}},
+		}
+	}
+	return fields, update, nil
+}
+
+// findFieldMeta looks a field up by ID, then by name (case-insensitive).
+func findFieldMeta(fields map[string]JiraFieldMeta, name string) (string, JiraFieldMeta, bool) {
+	if meta, ok := fields[name]; ok {
+		return name, meta, true
+	}
+	for id, meta := range fields {
+		if strings.EqualFold(id, name) || strings.EqualFold(meta.Name, name) {
+			return id, meta, true
+		}
+	}
+	return "", JiraFieldMeta{}, false
+}
+
+// fieldValue turns a command-line value into the JSON shape Jira expects for
+// the field's schema. A value starting with { or [ is sent as raw JSON.
+func fieldValue(cfg Config, id string, meta JiraFieldMeta, value string) (any, error) {
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var v any
+		if err := json.Unmarshal([]byte(trimmed), &v); err != nil {
+			return nil, fmt.Errorf("invalid JSON value: %w", err)
+		}
+		return v, nil
+	}
+
+	switch meta.Schema.Type {
+	case "number":
+		n, err := strconv.ParseFloat(trimmed, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", value)
+		}
+		return n, nil
+	case "user":
+		u, err := resolveUser(cfg, trimmed)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"accountId": u.AccountID}, nil
+	case "array":
+		var items []any
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			if meta.Schema.Items == "string" {
+				items = append(items, part)
+				continue
+			}
+			item, err := allowedValueRef(meta, part)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case "string":
+		if isRichTextField(id, meta) {
+			return textToADF(value), nil
+		}
+		return value, nil
+	case "date", "datetime", "":
+		return value, nil
+	default:
+		// option, resolution, priority, version, component, ...
+		return allowedValueRef(meta, trimmed)
+	}
+}
+
+// allowedValueRef references an allowed value by ID, matching on name, value
+// or ID. Without allowed values the name is passed through.
+func allowedValueRef(meta JiraFieldMeta, value string) (any, error) {
+	if len(meta.AllowedValues) == 0 {
+		return map[string]string{"name": value}, nil
+	}
+	for _, av := range meta.AllowedValues {
+		if strings.EqualFold(av.Name, value) || strings.EqualFold(av.Value, value) || av.ID == value {
+			return map[string]string{"id": av.ID}, nil
+		}
+	}
+	return nil, fmt.Errorf("%q is not allowed; one of: %s", value, strings.Join(allowedValueNames(meta), ", "))
+}
+
+func isRichTextField(id string, meta JiraFieldMeta) bool {
+	switch id {
+	case "description", "environment":
+		return true
+	}
+	return strings.HasSuffix(meta.Schema.Custom, ":textarea")
+}
+
+func fieldMetaToView(id string, meta JiraFieldMeta) FieldMetaView {
+	view := FieldMetaView{ID: id, Name: meta.Name, Type: meta.Schema.Type, Required: meta.Required, HasDefault: meta.HasDefaultValue}
+	if meta.Schema.Type == "array" && meta.Schema.Items != "" {
+		view.Type = "array<" + meta.Schema.Items + ">"
+	}
+	view.AllowedValues = allowedValueNames(meta)
+	return view
+}
+
+func allowedValueNames(meta JiraFieldMeta) []string {
+	var names []string
+	for _, av := range meta.AllowedValues {
+		names = append(names, firstNonEmpty(av.Name, av.Value, av.ID))
+	}
+	return names
+}
+
+func fieldMetaNames(fields map[string]JiraFieldMeta) []string {
+	names := make([]string, 0, len(fields))
+	for id, meta := range fields {
+		names = append(names, fmt.Sprintf("%s (%s)", meta.Name, id))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseFieldAssignments parses repeated --field NAME=VALUE flags.
+func parseFieldAssignments(values []string) (map[string]string, error) {
+	out := map[string]string{}
+	for _, v := range values {
+		name, value, ok := strings.Cut(v, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --field %q; expected NAME=VALUE", v)
+		}
+		out[name] = value
+	}
+	return out, nil
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTransitionIssueSendsScreenFieldsAndReportsMissing(t *testing.T) {
+	var posts []map[string]any
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/PROJ-5/transitions" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if r.Method == http.MethodGet {
+			if got := r.URL.Query().Get("expand"); got != "transitions.fields" {
+				t.Fatalf("expected expand=transitions.fields, got %q", got)
+			}
+			writeJSON(t, w, map[string]any{"transitions": []map[string]any{{
+				"id":   "41",
+				"name": "Done",
+				"fields": map[string]any{
+					"resolution": map[string]any{
+						"required": true, "name": "Resolution", "schema": map[string]any{"type": "resolution", "system": "resolution"},
+						"allowedValues": []map[string]any{{"id": "1", "name": "Fixed"}, {"id": "2", "name": "Won't Do"}},
+					},
+					"customfield_100": map[string]any{
+						"required": false, "name": "Story Points", "schema": map[string]any{"type": "number"},
+					},
+				},
+			}}})
+			return
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode transition: %v", err)
+		}
+		posts = append(posts, body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+
+	_, err := transitionIssue(cfg, "PROJ-5", "Done", TransitionOptions{}, false)
+	var missing *MissingFieldsError
+	if !errors.As(err, &missing) {
+		t.Fatalf("expected MissingFieldsError, got %v", err)
+	}
+	if missing.Code() != "MISSING_FIELDS" || len(missing.Fields) != 1 || missing.Fields[0].ID != "resolution" {
+		t.Fatalf("unexpected missing fields %+v", missing)
+	}
+	if got := missing.Fields[0].AllowedValues; len(got) != 2 || got[0] != "Fixed" {
+		t.Fatalf("expected allowed resolutions, got %v", got)
+	}
+	if len(posts) != 0 {
+		t.Fatalf("expected no POST when fields are missing")
+	}
+
+	opts := TransitionOptions{
+		Resolution: "fixed",
+		Fields:     map[string]string{"story points": "3"},
+		Comment:    "Shipped",
+	}
+	result, err := transitionIssue(cfg, "PROJ-5", "Done", opts, false)
+	if err != nil {
+		t.Fatalf("transitionIssue returned error: %v", err)
+	}
+	if len(posts) != 1 {
+		t.Fatalf("expected a single POST, got %d", len(posts))
+	}
+	got, _ := json.Marshal(posts[0]["fields"])
+	if string(got) != `{"customfield_100":3,"resolution":{"id":"1"}}` {
+		t.Fatalf("unexpected fields %s", got)
+	}
+	update, _ := json.Marshal(posts[0]["update"])
+	if !containsAll(string(update), []string{`"comment"`, `"add"`, `"Shipped"`}) {
+		t.Fatalf("expected comment in update block, got %s", update)
+	}
+	if len(result.Fields) != 2 || result.Comment != "Shipped" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+}
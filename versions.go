@@ -0,0 +1,467 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// ---------------------------------------------------------------------------
+// Jira API response types
+// ---------------------------------------------------------------------------
+
+type JiraVersion struct {
+	ID          string `json:"id"`
+	Self        string `json:"self"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Released    bool   `json:"released"`
+	Archived    bool   `json:"archived"`
+	Overdue     bool   `json:"overdue"`
+	StartDate   string `json:"startDate"`
+	ReleaseDate string `json:"releaseDate"`
+}
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type VersionView struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Released    bool   `json:"released"`
+	Archived    bool   `json:"archived,omitempty"`
+	Overdue     bool   `json:"overdue,omitempty"`
+	StartDate   string `json:"start_date,omitempty"`
+	ReleaseDate string `json:"release_date,omitempty"`
+}
+
+type VersionListView struct {
+	Project  string        `json:"project"`
+	Count    int           `json:"count"`
+	Versions []VersionView `json:"versions"`
+}
+
+type VersionResult struct {
+	Project string      `json:"project"`
+	Version VersionView `json:"version"`
+	// Unresolved are the issues still open on the version when it was
+	// released; they were moved to MovedTo when set.
+	Unresolved []string `json:"unresolved,omitempty"`
+	MovedTo    string   `json:"moved_to,omitempty"`
+	DryRun     bool     `json:"dry_run,omitempty"`
+}
+
+type EditResult struct {
+	Key    string                      `json:"key"`
+	Fields map[string]any              `json:"fields,omitempty"`
+	Update map[string][]map[string]any `json:"update,omitempty"`
+	DryRun bool                        `json:"dry_run,omitempty"`
+	URL    string                      `json:"url"`
+}
+
+// ---------------------------------------------------------------------------
+// Help functions
+// ---------------------------------------------------------------------------
+
+func printVersionsHelp() {
+	fmt.Println("jiractl versions commands:")
+	fmt.Println("  versions list     --project KEY [--unreleased] [--json]")
+	fmt.Println("  versions create   --project KEY --name NAME [--description TEXT] [--start-date YYYY-MM-DD] [--release-date YYYY-MM-DD] [--dry-run] [--json]")
+	fmt.Println("  versions release  NAME --project KEY [--date YYYY-MM-DD] [--move-unresolved-to NAME] [--dry-run] [--json]")
+}
+
+// ---------------------------------------------------------------------------
+// Versions commands
+// ---------------------------------------------------------------------------
+
+func runVersions(args []string) error {
+	if len(args) == 0 {
+		printVersionsHelp()
+		return nil
+	}
+
+	switch args[0] {
+	case "list":
+		return runVersionsList(args[1:])
+	case "create":
+		return runVersionsCreate(args[1:])
+	case "release":
+		return runVersionsRelease(args[1:])
+	case "help", "--help", "-h":
+		printVersionsHelp()
+		return nil
+	default:
+		printVersionsHelp()
+		return fmt.Errorf("unknown versions command %q", args[0])
+	}
+}
+
+func runVersionsList(args []string) error {
+	fs := flag.NewFlagSet("versions list", flag.ContinueOnError)
+	project := fs.String("project", "", "project key (required)")
+	unreleased := fs.Bool("unreleased", false, "only unreleased, unarchived versions")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *project == "" {
+		return errors.New("--project is required")
+	}
+	projectKey := strings.ToUpper(*project)
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	versions, err := getProjectVersions(cfg, projectKey)
+	if err != nil {
+		return err
+	}
+
+	out := VersionListView{Project: projectKey, Versions: []VersionView{}}
+	for _, v := range versions {
+		if *unreleased && (v.Released || v.Archived) {
+			continue
+		}
+		out.Versions = append(out.Versions, versionToView(v))
+	}
+	out.Count = len(out.Versions)
+
+	if *jsonOut {
+		return printJSON(out)
+	}
+
+	if out.Count == 0 {
+		fmt.Printf("No versions in %s.\n", projectKey)
+		return nil
+	}
+	fmt.Printf("Versions in %s (%d):\n\n", projectKey, out.Count)
+	for _, v := range out.Versions {
+		state := "unreleased"
+		switch {
+		case v.Archived:
+			state = "archived"
+		case v.Released:
+			state = "released"
+		case v.Overdue:
+			state = "overdue"
+		}
+		date := firstNonEmpty(v.ReleaseDate, "-")
+		fmt.Printf("- %-16s  %-10s  %s  (id %s)\n", v.Name, state, date, v.ID)
+	}
+	return nil
+}
+
+func runVersionsCreate(args []string) error {
+	fs := flag.NewFlagSet("versions create", flag.ContinueOnError)
+	project := fs.String("project", "", "project key (required)")
+	name := fs.String("name", "", "version name, e.g. 2.4.0 (required)")
+	description := fs.String("description", "", "version description")
+	startDate := fs.String("start-date", "", "start date (YYYY-MM-DD)")
+	releaseDate := fs.String("release-date", "", "planned release date (YYYY-MM-DD)")
+	dryRun := fs.Bool("dry-run", false, "show the version without creating it")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if _, err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *project == "" || strings.TrimSpace(*name) == "" {
+		return errors.New("--project and --name are required (e.g. --project PROJ --name 2.4.0)")
+	}
+	for flagName, value := range map[string]string{"--start-date": *startDate, "--release-date": *releaseDate} {
+		if err := validateDate(flagName, value); err != nil {
+			return err
+		}
+	}
+	projectKey := strings.ToUpper(*project)
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	// Check for an existing version first for a clearer error than Jira's.
+	versions, err := getProjectVersions(cfg, projectKey)
+	if err != nil {
+		return err
+	}
+	if v, ok := findVersion(versions, *name); ok {
+		return fmt.Errorf("version %q already exists in %s (id %s)", v.Name, projectKey, v.ID)
+	}
+
+	version := JiraVersion{Name: strings.TrimSpace(*name), Description: *description, StartDate: *startDate, ReleaseDate: *releaseDate}
+	if !*dryRun {
+		p, err := getProject(cfg, projectKey)
+		if err != nil {
+			return err
+		}
+		body := map[string]any{"projectId": p.ID, "name": version.Name}
+		if version.Description != "" {
+			body["description"] = version.Description
+		}
+		if version.StartDate != "" {
+			body["startDate"] = version.StartDate
+		}
+		if version.ReleaseDate != "" {
+			body["releaseDate"] = version.ReleaseDate
+		}
+		if err := jiraDo(cfg, http.MethodPost, "/rest/api/3/version", nil, body, &version); err != nil {
+			return err
+		}
+	}
+
+	result := VersionResult{Project: projectKey, Version: versionToView(version), DryRun: *dryRun}
+	if *jsonOut {
+		return printJSON(result)
+	}
+	if *dryRun {
+		fmt.Printf("Would create version %s in %s\n", version.Name, projectKey)
+		return nil
+	}
+	fmt.Printf("Created version %s in %s (id %s)\n", version.Name, projectKey, version.ID)
+	return nil
+}
+
+func runVersionsRelease(args []string) error {
+	fs := flag.NewFlagSet("versions release", flag.ContinueOnError)
+	project := fs.String("project", "", "project key (required)")
+	date := fs.String("date", "", "release date (YYYY-MM-DD, default today)")
+	moveTo := fs.String("move-unresolved-to", "", "move unresolved issues to this version")
+	dryRun := fs.Bool("dry-run", false, "show what would happen without releasing")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 || *project == "" {
+		return errors.New("version name and --project are required (e.g. jiractl versions release 2.3.0 --project PROJ)")
+	}
+	if err := validateDate("--date", *date); err != nil {
+		return err
+	}
+	releaseDate := firstNonEmpty(*date, time.Now().Format("2006-01-02"))
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	result, err := releaseVersion(cfg, strings.ToUpper(*project), positional[0], releaseDate, *moveTo, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		return printJSON(result)
+	}
+
+	verb := "Released"
+	if result.DryRun {
+		verb = "Would release"
+	}
+	fmt.Printf("%s %s %s on %s\n", verb, result.Project, result.Version.Name, releaseDate)
+	if len(result.Unresolved) > 0 {
+		if result.MovedTo != "" {
+			fmt.Printf("%d unresolved issue(s) moved to %s: %s\n", len(result.Unresolved), result.MovedTo, strings.Join(result.Unresolved, ", "))
+		} else {
+			fmt.Fprintf(os.Stderr, "warning: %d unresolved issue(s) stay on %s: %s (use --move-unresolved-to)\n",
+				len(result.Unresolved), result.Version.Name, strings.Join(result.Unresolved, ", "))
+		}
+	}
+	return nil
+}
+
+func runIssuesEdit(args []string) error {
+	fs := flag.NewFlagSet("issues edit", flag.ContinueOnError)
+	summary := fs.String("summary", "", "new summary")
+	priority := fs.String("priority", "", "new priority name")
+	var setVersions, addVersions, removeVersions stringList
+	fs.Var(&setVersions, "fix-version", "replace fix versions with this version (repeatable)")
+	fs.Var(&addVersions, "add-fix-version", "add a fix version (repeatable)")
+	fs.Var(&removeVersions, "remove-fix-version", "remove a fix version (repeatable)")
+	dryRun := fs.Bool("dry-run", false, "show the changes without applying them")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("issue key is required (e.g. jiractl issues edit PROJ-1 --fix-version 2.4.0)")
+	}
+	issueKey := strings.ToUpper(positional[0])
+
+	fields := map[string]any{}
+	if *summary != "" {
+		fields["summary"] = *summary
+	}
+	if *priority != "" {
+		fields["priority"] = map[string]string{"name": *priority}
+	}
+	var update map[string][]map[string]any
+	switch {
+	case len(setVersions) > 0 && len(addVersions)+len(removeVersions) > 0:
+		return errors.New("--fix-version replaces all fix versions; don't combine it with --add-fix-version/--remove-fix-version")
+	case len(setVersions) > 0:
+		refs := make([]map[string]string, 0, len(setVersions))
+		for _, v := range setVersions {
+			refs = append(refs, map[string]string{"name": v})
+		}
+		fields["fixVersions"] = refs
+	case len(addVersions)+len(removeVersions) > 0:
+		if err := validateValueOps("fixVersions", addVersions, removeVersions); err != nil {
+			return err
+		}
+		update = map[string][]map[string]any{"fixVersions": valueOps("fixVersions", addVersions, removeVersions)}
+	}
+	if len(fields) == 0 && len(update) == 0 {
+		return errors.New("nothing to do: pass --summary, --priority or a fix version flag")
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	if !*dryRun {
+		if err := editIssue(cfg, issueKey, fields, update); err != nil {
+			return err
+		}
+	}
+
+	result := EditResult{Key: issueKey, Fields: fields, Update: update, DryRun: *dryRun, URL: cfg.Server + "/browse/" + issueKey}
+	if *jsonOut {
+		return printJSON(result)
+	}
+	prefix := "Updated"
+	if *dryRun {
+		prefix = "Would update"
+	}
+	fmt.Printf("%s %s: %s\n", prefix, issueKey, strings.Join(editedFieldNames(fields, update), ", "))
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Versions helpers
+// ---------------------------------------------------------------------------
+
+// releaseVersion marks a version released. Unresolved issues are listed first
+// so the caller can report them; with moveTo, Jira moves them to that
+// version as part of the release.
+func releaseVersion(cfg Config, projectKey, name, releaseDate, moveTo string, dryRun bool) (VersionResult, error) {
+	result := VersionResult{Project: projectKey, DryRun: dryRun}
+
+	versions, err := getProjectVersions(cfg, projectKey)
+	if err != nil {
+		return result, err
+	}
+	version, ok := findVersion(versions, name)
+	if !ok {
+		return result, fmt.Errorf("version %q not found in %s; available: %s", name, projectKey, strings.Join(versionNames(versions), ", "))
+	}
+	if version.Released {
+		return result, fmt.Errorf("version %s is already released", version.Name)
+	}
+
+	body := map[string]any{"released": true, "releaseDate": releaseDate}
+	if moveTo != "" {
+		target, ok := findVersion(versions, moveTo)
+		if !ok {
+			return result, fmt.Errorf("version %q not found in %s", moveTo, projectKey)
+		}
+		if target.ID == version.ID {
+			return result, errors.New("--move-unresolved-to must name a different version")
+		}
+		if target.Released || target.Archived {
+			return result, fmt.Errorf("cannot move issues to %s: it is released or archived", target.Name)
+		}
+		body["moveUnfixedIssuesTo"] = target.Self
+		result.MovedTo = target.Name
+	}
+
+	jql := fmt.Sprintf("project = %q AND fixVersion = %s AND resolution = EMPTY ORDER BY key ASC", projectKey, version.ID)
+	unresolved, err := searchIssuesWithFields(cfg, jql, 1000, "summary")
+	if err != nil {
+		return result, err
+	}
+	for _, issue := range unresolved.Issues {
+		result.Unresolved = append(result.Unresolved, issue.Key)
+	}
+	if len(result.Unresolved) == 0 {
+		result.MovedTo = ""
+	}
+
+	version.Released = true
+	version.ReleaseDate = releaseDate
+	if !dryRun {
+		if err := jiraDo(cfg, http.MethodPut, "/rest/api/3/version/"+url.PathEscape(version.ID), nil, body, &version); err != nil {
+			return result, err
+		}
+	}
+	result.Version = versionToView(version)
+	return result, nil
+}
+
+func getProjectVersions(cfg Config, projectKey string) ([]JiraVersion, error) {
+	var versions []JiraVersion
+	err := jiraDo(cfg, http.MethodGet, "/rest/api/3/project/"+url.PathEscape(projectKey)+"/versions", nil, nil, &versions)
+	return versions, err
+}
+
+// findVersion looks a version up by name (case-insensitive) or ID.
+func findVersion(versions []JiraVersion, name string) (JiraVersion, bool) {
+	name = strings.TrimSpace(name)
+	for _, v := range versions {
+		if strings.EqualFold(v.Name, name) || v.ID == name {
+			return v, true
+		}
+	}
+	return JiraVersion{}, false
+}
+
+func versionNames(versions []JiraVersion) []string {
+	names := make([]string, 0, len(versions))
+	for _, v := range versions {
+		names = append(names, v.Name)
+	}
+	return names
+}
+
+func versionToView(v JiraVersion) VersionView {
+	return VersionView{
+		ID:          v.ID,
+		Name:        v.Name,
+		Description: v.Description,
+		Released:    v.Released,
+		Archived:    v.Archived,
+		Overdue:     v.Overdue,
+		StartDate:   v.StartDate,
+		ReleaseDate: v.ReleaseDate,
+	}
+}
+
+func validateDate(flagName, value string) error {
+	if value == "" {
+		return nil
+	}
+	if _, err := time.Parse("2006-01-02", value); err != nil {
+		return fmt.Errorf("%s must be YYYY-MM-DD, got %q", flagName, value)
+	}
+	return nil
+}
+
+func editedFieldNames(fields map[string]any, update map[string][]map[string]any) []string {
+	names := sortedKeys(fields)
+	for _, name := range sortedKeys(update) {
+		if !containsString(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
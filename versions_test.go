@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReleaseVersionMovesUnresolvedIssues(t *testing.T) {
+	var released map[string]any
+	var searched string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/project/PROJ/versions", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, []JiraVersion{
+			{ID: "10", Self: "https://jira/rest/api/3/version/10", Name: "2.3.0"},
+			{ID: "11", Self: "https://jira/rest/api/3/version/11", Name: "2.4.0"},
+		})
+	})
+	mux.HandleFunc("/rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		searched = r.URL.Query().Get("jql")
+		writeJSON(t, w, JiraSearchResponse{Issues: []JiraIssue{{Key: "PROJ-7"}, {Key: "PROJ-9"}}})
+	})
+	mux.HandleFunc("/rest/api/3/version/10", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Fatalf("expected PUT, got %s", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&released); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		writeJSON(t, w, JiraVersion{ID: "10", Name: "2.3.0", Released: true, ReleaseDate: "2026-10-18"})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	result, err := releaseVersion(cfg, "PROJ", "2.3.0", "2026-10-18", "2.4.0", false)
+	if err != nil {
+		t.Fatalf("releaseVersion returned error: %v", err)
+	}
+
+	if released["released"] != true || released["releaseDate"] != "2026-10-18" {
+		t.Fatalf("unexpected release body %v", released)
+	}
+	if released["moveUnfixedIssuesTo"] != "https://jira/rest/api/3/version/11" {
+		t.Fatalf("expected unresolved issues to move to 2.4.0, got %v", released["moveUnfixedIssuesTo"])
+	}
+	if !strings.Contains(searched, "fixVersion = 10 AND resolution = EMPTY") {
+		t.Fatalf("unexpected unresolved search %q", searched)
+	}
+	if result.MovedTo != "2.4.0" || strings.Join(result.Unresolved, ",") != "PROJ-7,PROJ-9" || !result.Version.Released {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	if _, err := releaseVersion(cfg, "PROJ", "2.3.0", "2026-10-18", "2.3.0", true); err == nil {
+		t.Fatal("expected error when moving issues to the released version itself")
+	}
+}
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// watchFields are the issue fields watch snapshots and diffs.
+const watchFields = "summary,status,issuetype,priority,assignee,labels,components,resolution,created,updated,comment"
+
+// Watch event types.
+const (
+	eventCreated       = "created"
+	eventStatusChanged = "status_changed"
+	eventAssigned      = "assigned"
+	eventCommented     = "commented"
+	eventFieldChanged  = "field_changed"
+)
+
+// ---------------------------------------------------------------------------
+// Watch types
+// ---------------------------------------------------------------------------
+
+// WatchSnapshot is the last seen state of one issue.
+type WatchSnapshot struct {
+	Summary    string   `json:"summary"`
+	Status     string   `json:"status"`
+	Type       string   `json:"type"`
+	Priority   string   `json:"priority"`
+	Assignee   string   `json:"assignee"`
+	Labels     []string `json:"labels,omitempty"`
+	Components []string `json:"components,omitempty"`
+	Resolution string   `json:"resolution,omitempty"`
+	Comments   int      `json:"comments"`
+	Updated    string   `json:"updated"`
+}
+
+// WatchState is persisted between polls so a restarted watch resumes from
+// the last snapshot instead of replaying events.
+type WatchState struct {
+	JQL      string    `json:"jql"`
+	LastPoll time.Time `json:"last_poll"`
+	// Partial is set while the baseline is still being recorded because
+	// more issues matched than --limit.
+	Partial bool `json:"partial,omitempty"`
+	// Resume is set when the last poll stopped at --limit; LastPoll is then
+	// the update time of the last issue fetched.
+	Resume bool                     `json:"resume,omitempty"`
+	Issues map[string]WatchSnapshot `json:"issues"`
+}
+
+type WatchEvent struct {
+	Type    string       `json:"type"`
+	Key     string       `json:"key"`
+	Summary string       `json:"summary"`
+	Field   string       `json:"field,omitempty"`
+	Before  any          `json:"before,omitempty"`
+	After   any          `json:"after,omitempty"`
+	Comment *CommentView `json:"comment,omitempty"`
+	Updated string       `json:"updated"`
+	URL     string       `json:"url"`
+}
+
+// ---------------------------------------------------------------------------
+// Watch command
+// ---------------------------------------------------------------------------
+
+func runWatch(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	jql := fs.String("jql", "", "JQL query to watch (required)")
+	interval := fs.Duration("interval", time.Minute, "time between polls")
+	format := fs.String("format", "text", "output format: text or ndjson")
+	statePath := fs.String("state", "", "file to keep the snapshot in, so restarts resume without replaying events")
+	once := fs.Bool("once", false, "poll once and exit")
+	limit := fs.Int("limit", 1000, "max issues to fetch per poll")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if strings.TrimSpace(*jql) == "" {
+		return errors.New("--jql is required (e.g. --jql \"project = PROJ\")")
+	}
+	if *format != "text" && *format != "ndjson" {
+		return fmt.Errorf("--format must be text or ndjson, got %q", *format)
+	}
+	if *interval < 10*time.Second {
+		return errors.New("--interval must be at least 10s")
+	}
+	if *limit <= 0 {
+		return errors.New("--limit must be greater than 0")
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	state, err := loadWatchState(*statePath, *jql)
+	if err != nil {
+		return err
+	}
+	emit := newEventWriter(os.Stdout, *format)
+
+	for {
+		events, err := pollWatch(cfg, &state, *limit, time.Now())
+		if err != nil {
+			if *once {
+				return err
+			}
+			// Keep watching through transient failures; the next poll covers
+			// the gap because the poll time was not advanced.
+			fmt.Fprintf(os.Stderr, "watch: poll failed: %v\n", err)
+		} else {
+			for _, e := range events {
+				if err := emit(e); err != nil {
+					return err
+				}
+			}
+			if err := saveWatchState(*statePath, state); err != nil {
+				return err
+			}
+		}
+		if *once {
+			return nil
+		}
+		time.Sleep(*interval)
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Watch helpers
+// ---------------------------------------------------------------------------
+
+// pollWatch fetches the issues updated since the last poll and diffs them
+// against the snapshot. The first poll only records a baseline. The state is
+// only changed when the poll succeeds.
+func pollWatch(cfg Config, state *WatchState, limit int, now time.Time) ([]WatchEvent, error) {
+	base := strings.TrimSpace(orderByPattern.ReplaceAllString(state.JQL, ""))
+	baseline := state.LastPoll.IsZero() || state.Partial
+	query := base
+	if !state.LastPoll.IsZero() {
+		// Resuming after --limit skips the overlap, or a burst of more than
+		// limit updates would be fetched again on every poll.
+		overlap := syncOverlap
+		if state.Resume {
+			overlap = 0
+		}
+		query = updatedSinceJQL(base, state.LastPoll, now, overlap)
+	}
+
+	found, err := searchIssuesWithFields(cfg, query+" ORDER BY updated ASC", limit, watchFields)
+	if err != nil {
+		return nil, err
+	}
+	if found.HasMore {
+		fmt.Fprintf(os.Stderr, "watch: more than %d issues changed; raise --limit\n", limit)
+	}
+
+	if state.Issues == nil {
+		state.Issues = map[string]WatchSnapshot{}
+	}
+	var events []WatchEvent
+	for _, issue := range found.Issues {
+		snap := snapshotIssue(issue)
+		if !baseline {
+			prev, seen := state.Issues[issue.Key]
+			events = append(events, diffSnapshots(issue, prev, seen, snap, cfg.Server)...)
+		}
+		state.Issues[issue.Key] = snap
+	}
+
+	state.LastPoll = now.UTC()
+	state.Partial, state.Resume = false, false
+	if n := len(found.Issues); found.HasMore && n > 0 {
+		// Results are oldest first, so resume from the last issue fetched
+		// and let the next poll pick up the changes beyond --limit.
+		if t, err := parseJiraTime(found.Issues[n-1].Fields.Updated); err == nil {
+			state.LastPoll = t.UTC()
+			state.Partial, state.Resume = baseline, true
+		}
+	}
+	return events, nil
+}
+
+func snapshotIssue(issue JiraIssue) WatchSnapshot {
+	f := issue.Fields
+	snap := WatchSnapshot{
+		Summary:    f.Summary,
+		Status:     nameOrEmpty(f.Status),
+		Type:       nameOrEmpty(f.IssueType),
+		Priority:   nameOrEmpty(f.Priority),
+		Assignee:   userEmail(f.Assignee),
+		Labels:     f.Labels,
+		Components: componentNames(f.Components),
+		Resolution: nameOrEmpty(f.Resolution),
+		Updated:    f.Updated,
+	}
+	if f.Comment != nil {
+		snap.Comments = maxInt(f.Comment.Total, len(f.Comment.Comments))
+	}
+	return snap
+}
+
+// diffSnapshots turns the changes between two snapshots of an issue into
+// events. An issue not seen before is reported as created, whether it is new
+// or has just started matching the query.
+func diffSnapshots(issue JiraIssue, prev WatchSnapshot, seen bool, cur WatchSnapshot, server string) []WatchEvent {
+	event := func(typ, field string, before, after any) WatchEvent {
+		return WatchEvent{
+			Type:    typ,
+			Key:     issue.Key,
+			Summary: cur.Summary,
+			Field:   field,
+			Before:  before,
+			After:   after,
+			Updated: cur.Updated,
+			URL:     server + "/browse/" + issue.Key,
+		}
+	}
+
+	if !seen {
+		return []WatchEvent{event(eventCreated, "", nil, issueToView(issue, server))}
+	}
+
+	var events []WatchEvent
+	if prev.Status != cur.Status {
+		events = append(events, event(eventStatusChanged, "status", prev.Status, cur.Status))
+	}
+	if prev.Assignee != cur.Assignee {
+		events = append(events, event(eventAssigned, "assignee", prev.Assignee, cur.Assignee))
+	}
+	for _, f := range []struct {
+		name          string
+		before, after string
+	}{
+		{"summary", prev.Summary, cur.Summary},
+		{"type", prev.Type, cur.Type},
+		{"priority", prev.Priority, cur.Priority},
+		{"labels", strings.Join(prev.Labels, ","), strings.Join(cur.Labels, ",")},
+		{"components", strings.Join(prev.Components, ","), strings.Join(cur.Components, ",")},
+		{"resolution", prev.Resolution, cur.Resolution},
+	} {
+		if f.before != f.after {
+			events = append(events, event(eventFieldChanged, f.name, f.before, f.after))
+		}
+	}
+	if cur.Comments > prev.Comments {
+		e := event(eventCommented, "comments", prev.Comments, cur.Comments)
+		if c := issue.Fields.Comment; c != nil && len(c.Comments) > 0 && len(c.Comments) == cur.Comments {
+			last := c.Comments[len(c.Comments)-1]
+			e.Comment = &CommentView{Author: userDisplayName(last.Author), Body: adfToText(last.Body), Created: formatDate(last.Created)}
+		}
+		events = append(events, e)
+	}
+	return events
+}
+
+// loadWatchState reads the state file, starting fresh when there is none or
+// when it was written for a different query.
+func loadWatchState(path, jql string) (WatchState, error) {
+	fresh := WatchState{JQL: jql, Issues: map[string]WatchSnapshot{}}
+	if path == "" {
+		return fresh, nil
+	}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return fresh, nil
+	}
+	if err != nil {
+		return fresh, err
+	}
+	var state WatchState
+	if err := json.Unmarshal(b, &state); err != nil {
+		return fresh, fmt.Errorf("corrupt watch state %s: %w", path, err)
+	}
+	if state.JQL != jql {
+		fmt.Fprintf(os.Stderr, "watch: %s was written for a different query; starting over\n", path)
+		return fresh, nil
+	}
+	return state, nil
+}
+
+func saveWatchState(path string, state WatchState) error {
+	if path == "" {
+		return nil
+	}
+	return writeFileAtomic(path, state)
+}
+
+// newEventWriter returns a function writing one event per line, as JSON for
+// ndjson or as a short description for text.
+func newEventWriter(w io.Writer, format string) func(WatchEvent) error {
+	if format == "ndjson" {
+		enc := json.NewEncoder(w)
+		enc.SetEscapeHTML(false)
+		return func(e WatchEvent) error { return enc.Encode(e) }
+	}
+	return func(e WatchEvent) error {
+		var detail string
+		switch e.Type {
+		case eventCreated:
+			detail = e.Summary
+		case eventCommented:
+			detail = fmt.Sprintf("%v -> %v comments", e.Before, e.After)
+			if e.Comment != nil {
+				detail += fmt.Sprintf(" (%s)", e.Comment.Author)
+			}
+		default:
+			detail = fmt.Sprintf("%s: %v -> %v", e.Field, firstNonEmpty(fmt.Sprint(e.Before), "-"), firstNonEmpty(fmt.Sprint(e.After), "-"))
+		}
+		_, err := fmt.Fprintf(w, "%s  %-12s  %-14s  %s\n", time.Now().Format("15:04:05"), e.Key, e.Type, detail)
+		return err
+	}
+}
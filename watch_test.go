@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPollWatchEmitsTypedEventsAndResumesFromState(t *testing.T) {
+	issue := func(key, status, assignee string, comments int) JiraIssue {
+		fields := JiraIssueFields{
+			Summary: "Fix exporter", Status: &JiraNameField{Name: status},
+			Updated: "2026-03-10T10:00:00.000+0000",
+			Comment: &JiraCommentsResponse{Total: comments},
+		}
+		if assignee != "" {
+			fields.Assignee = &JiraUser{EmailAddress: assignee}
+		}
+		return JiraIssue{Key: key, Fields: fields}
+	}
+	responses := [][]JiraIssue{
+		{issue("PROJ-1", "To Do", "", 0)},
+		{issue("PROJ-1", "In Progress", "dev@example.com", 1), issue("PROJ-2", "To Do", "", 0)},
+		{issue("PROJ-1", "In Progress", "dev@example.com", 1)},
+	}
+	var queries []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("jql"))
+		writeJSON(t, w, JiraSearchResponse{Issues: responses[len(queries)-1]})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	statePath := filepath.Join(t.TempDir(), "watch.json")
+	now := time.Date(2026, 3, 10, 10, 0, 0, 0, time.UTC)
+
+	state, err := loadWatchState(statePath, "project = PROJ")
+	if err != nil {
+		t.Fatalf("loadWatchState returned error: %v", err)
+	}
+	events, err := pollWatch(cfg, &state, 100, now)
+	if err != nil || len(events) != 0 {
+		t.Fatalf("baseline poll should emit nothing, got %v, %v", events, err)
+	}
+	if err := saveWatchState(statePath, state); err != nil {
+		t.Fatalf("saveWatchState returned error: %v", err)
+	}
+
+	// A restart resumes from the saved snapshot.
+	state, err = loadWatchState(statePath, "project = PROJ")
+	if err != nil {
+		t.Fatalf("reloading state returned error: %v", err)
+	}
+	events, err = pollWatch(cfg, &state, 100, now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("second poll returned error: %v", err)
+	}
+	if !strings.HasPrefix(queries[1], `(project = PROJ) AND updated >= "-7m"`) {
+		t.Fatalf("unexpected incremental JQL %q", queries[1])
+	}
+
+	var buf bytes.Buffer
+	emit := newEventWriter(&buf, "ndjson")
+	var types []string
+	for _, e := range events {
+		types = append(types, e.Key+":"+e.Type)
+		if err := emit(e); err != nil {
+			t.Fatalf("emit returned error: %v", err)
+		}
+	}
+	if got := strings.Join(types, ","); got != "PROJ-1:status_changed,PROJ-1:assigned,PROJ-1:commented,PROJ-2:created" {
+		t.Fatalf("unexpected events %q", got)
+	}
+	var first WatchEvent
+	if err := json.Unmarshal([]byte(strings.SplitN(buf.String(), "\n", 2)[0]), &first); err != nil {
+		t.Fatalf("first line is not JSON: %v", err)
+	}
+	if first.Before != "To Do" || first.After != "In Progress" {
+		t.Fatalf("unexpected status change %+v", first)
+	}
+
+	// Re-fetching an unchanged issue in the overlap window emits nothing.
+	events, err = pollWatch(cfg, &state, 100, now.Add(2*time.Minute))
+	if err != nil || len(events) != 0 {
+		t.Fatalf("expected no events for an unchanged issue, got %v, %v", events, err)
+	}
+}
+
+func TestPollWatchResumesAfterLastFetchedIssueWhenLimited(t *testing.T) {
+	var queries []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("jql"))
+		writeJSON(t, w, JiraSearchResponse{Total: 2, Issues: []JiraIssue{{Key: "PROJ-1", Fields: JiraIssueFields{
+			Summary: "Fix exporter", Status: &JiraNameField{Name: "Done"}, Updated: "2026-03-10T09:50:00.000+0000",
+		}}}})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	now := time.Date(2026, 3, 10, 10, 0, 0, 0, time.UTC)
+	state := WatchState{
+		JQL:      "project = PROJ",
+		LastPoll: now.Add(-time.Hour),
+		Issues:   map[string]WatchSnapshot{"PROJ-1": {Summary: "Fix exporter", Status: "To Do"}},
+	}
+
+	events, err := pollWatch(cfg, &state, 1, now)
+	if err != nil || len(events) != 1 || events[0].Type != eventStatusChanged {
+		t.Fatalf("expected one status change, got %v, %v", events, err)
+	}
+	if want := time.Date(2026, 3, 10, 9, 50, 0, 0, time.UTC); !state.LastPoll.Equal(want) || state.Partial {
+		t.Fatalf("expected to resume from %s, got %s (partial %v)", want, state.LastPoll, state.Partial)
+	}
+
+	// The resumed poll has no overlap, so a burst of updates beyond --limit
+	// within a few minutes can't pin the watch to the same first page.
+	if _, err := pollWatch(cfg, &state, 1, now); err != nil {
+		t.Fatalf("second poll returned error: %v", err)
+	}
+	if got := queries[1]; got != `(project = PROJ) AND updated >= "-11m" ORDER BY updated ASC` {
+		t.Fatalf("expected a resume without overlap, got %q", got)
+	}
+}
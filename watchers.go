@@ -0,0 +1,264 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ---------------------------------------------------------------------------
+// Jira API response types
+// ---------------------------------------------------------------------------
+
+type JiraWatchersResponse struct {
+	IsWatching bool       `json:"isWatching"`
+	WatchCount int        `json:"watchCount"`
+	Watchers   []JiraUser `json:"watchers"`
+}
+
+type JiraVotesResponse struct {
+	Votes    int  `json:"votes"`
+	HasVoted bool `json:"hasVoted"`
+}
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type WatcherView struct {
+	AccountID string `json:"account_id"`
+	Name      string `json:"name"`
+	Email     string `json:"email,omitempty"`
+}
+
+type WatchersView struct {
+	Key        string        `json:"key"`
+	IsWatching bool          `json:"is_watching"`
+	Count      int           `json:"count"`
+	Watchers   []WatcherView `json:"watchers"`
+}
+
+type WatchResult struct {
+	Key    string        `json:"key"`
+	Action string        `json:"action"`
+	Users  []WatcherView `json:"users"`
+	DryRun bool          `json:"dry_run,omitempty"`
+}
+
+type VoteResult struct {
+	Key      string `json:"key"`
+	HasVoted bool   `json:"has_voted"`
+	Votes    int    `json:"votes"`
+}
+
+// ---------------------------------------------------------------------------
+// Watcher and vote commands
+// ---------------------------------------------------------------------------
+
+func runIssuesWatch(args []string, add bool) error {
+	name := "issues unwatch"
+	if add {
+		name = "issues watch"
+	}
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	var users stringList
+	fs.Var(&users, "user", "user email or name (repeatable; default: you)")
+	dryRun := fs.Bool("dry-run", false, "resolve users without changing watchers")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return fmt.Errorf("issue key is required (e.g. jiractl %s PROJ-123)", name)
+	}
+	issueKey := strings.ToUpper(positional[0])
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	result, err := changeWatchers(cfg, issueKey, users, add, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		return printJSON(result)
+	}
+
+	verb := "Removed watcher"
+	switch {
+	case add && *dryRun:
+		verb = "Would add watcher"
+	case add:
+		verb = "Added watcher"
+	case *dryRun:
+		verb = "Would remove watcher"
+	}
+	for _, u := range result.Users {
+		fmt.Printf("%s %s on %s\n", verb, u.Name, issueKey)
+	}
+	return nil
+}
+
+func runIssuesWatchers(args []string) error {
+	fs := flag.NewFlagSet("issues watchers", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("issue key is required (e.g. jiractl issues watchers PROJ-123)")
+	}
+	issueKey := strings.ToUpper(positional[0])
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	resp, err := getWatchers(cfg, issueKey)
+	if err != nil {
+		return err
+	}
+
+	view := WatchersView{
+		Key:        issueKey,
+		IsWatching: resp.IsWatching,
+		Count:      resp.WatchCount,
+		Watchers:   make([]WatcherView, 0, len(resp.Watchers)),
+	}
+	for _, u := range resp.Watchers {
+		view.Watchers = append(view.Watchers, userToWatcherView(u))
+	}
+
+	if *jsonOut {
+		return printJSON(view)
+	}
+
+	if view.Count == 0 {
+		fmt.Printf("No watchers on %s.\n", issueKey)
+		return nil
+	}
+	fmt.Printf("Watchers on %s (%d):\n", issueKey, view.Count)
+	for _, w := range view.Watchers {
+		if w.Email != "" {
+			fmt.Printf("- %s <%s>\n", w.Name, w.Email)
+		} else {
+			fmt.Printf("- %s\n", w.Name)
+		}
+	}
+	if view.IsWatching {
+		fmt.Println("You are watching this issue.")
+	}
+	return nil
+}
+
+func runIssuesVote(args []string, add bool) error {
+	name := "issues unvote"
+	if add {
+		name = "issues vote"
+	}
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return fmt.Errorf("issue key is required (e.g. jiractl %s PROJ-123)", name)
+	}
+	issueKey := strings.ToUpper(positional[0])
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	path := "/rest/api/3/issue/" + url.PathEscape(issueKey) + "/votes"
+	method := http.MethodDelete
+	if add {
+		method = http.MethodPost
+	}
+	if err := jiraDo(cfg, method, path, nil, nil, nil); err != nil {
+		return err
+	}
+
+	var votes JiraVotesResponse
+	if err := jiraDo(cfg, http.MethodGet, path, nil, nil, &votes); err != nil {
+		return err
+	}
+	result := VoteResult{Key: issueKey, HasVoted: votes.HasVoted, Votes: votes.Votes}
+
+	if *jsonOut {
+		return printJSON(result)
+	}
+	if add {
+		fmt.Printf("Voted for %s (%d votes)\n", issueKey, result.Votes)
+	} else {
+		fmt.Printf("Removed vote from %s (%d votes)\n", issueKey, result.Votes)
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Jira API calls
+// ---------------------------------------------------------------------------
+
+func getWatchers(cfg Config, issueKey string) (JiraWatchersResponse, error) {
+	var resp JiraWatchersResponse
+	err := jiraDo(cfg, http.MethodGet, "/rest/api/3/issue/"+url.PathEscape(issueKey)+"/watchers", nil, nil, &resp)
+	return resp, err
+}
+
+// changeWatchers resolves each user query (default "me") and adds or removes
+// them as watchers. All users are resolved before any change is made.
+func changeWatchers(cfg Config, issueKey string, queries []string, add, dryRun bool) (WatchResult, error) {
+	result := WatchResult{Key: issueKey, Action: "unwatch", DryRun: dryRun}
+	if add {
+		result.Action = "watch"
+	}
+	if len(queries) == 0 {
+		queries = []string{"me"}
+	}
+
+	var users []JiraUser
+	for _, q := range queries {
+		u, err := resolveUser(cfg, q)
+		if err != nil {
+			return result, err
+		}
+		users = append(users, u)
+		result.Users = append(result.Users, userToWatcherView(u))
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	path := "/rest/api/3/issue/" + url.PathEscape(issueKey) + "/watchers"
+	for _, u := range users {
+		var err error
+		if add {
+			// The body is the bare account ID as a JSON string.
+			err = jiraDo(cfg, http.MethodPost, path, nil, u.AccountID, nil)
+		} else {
+			q := url.Values{}
+			q.Set("accountId", u.AccountID)
+			err = jiraDo(cfg, http.MethodDelete, path, q, nil, nil)
+		}
+		if err != nil {
+			return result, fmt.Errorf("failed to %s %s: %w", result.Action, userDisplayName(&u), err)
+		}
+	}
+	return result, nil
+}
+
+func userToWatcherView(u JiraUser) WatcherView {
+	return WatcherView{AccountID: u.AccountID, Name: userDisplayName(&u), Email: u.EmailAddress}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestChangeWatchersResolvesUsersBeforeAdding(t *testing.T) {
+	var added []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/user/search", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("query") {
+		case "lead@example.com":
+			writeJSON(t, w, []JiraUser{{AccountID: "acc-lead", DisplayName: "Lead"}})
+		case "oncall@example.com":
+			writeJSON(t, w, []JiraUser{{AccountID: "acc-oncall", DisplayName: "On Call"}})
+		default:
+			writeJSON(t, w, []JiraUser{})
+		}
+	})
+	mux.HandleFunc("/rest/api/3/issue/INC-4/watchers", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Fatalf("expected POST, got %s", r.Method)
+		}
+		var accountID string
+		if err := json.NewDecoder(r.Body).Decode(&accountID); err != nil {
+			t.Fatalf("expected a JSON string body: %v", err)
+		}
+		added = append(added, accountID)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+
+	if _, err := changeWatchers(cfg, "INC-4", []string{"lead@example.com", "nobody"}, true, false); err == nil {
+		t.Fatalf("expected error for unknown user")
+	}
+	if len(added) != 0 {
+		t.Fatalf("expected no watchers added when a user fails to resolve, got %v", added)
+	}
+
+	result, err := changeWatchers(cfg, "INC-4", []string{"lead@example.com", "oncall@example.com"}, true, false)
+	if err != nil {
+		t.Fatalf("changeWatchers returned error: %v", err)
+	}
+	if len(added) != 2 || added[0] != "acc-lead" || added[1] != "acc-oncall" {
+		t.Fatalf("unexpected watchers added: %v", added)
+	}
+	if result.Action != "watch" || len(result.Users) != 2 || result.Users[1].Name != "On Call" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+}
@@ -0,0 +1,446 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// maxPathHops bounds both the planned path and step-by-step exploration.
+const maxPathHops = 10
+
+// errWorkflowNotFound means the issue's workflow definition could not be
+// found, which for non-admins is how Jira hides it.
+var errWorkflowNotFound = errors.New("workflow definition not found")
+
+// ---------------------------------------------------------------------------
+// Jira API response types
+// ---------------------------------------------------------------------------
+
+type JiraWorkflowSchemeProjects struct {
+	Values []struct {
+		ProjectIDs     []string           `json:"projectIds"`
+		WorkflowScheme JiraWorkflowScheme `json:"workflowScheme"`
+	} `json:"values"`
+}
+
+type JiraWorkflowScheme struct {
+	ID                int               `json:"id"`
+	DefaultWorkflow   string            `json:"defaultWorkflow"`
+	IssueTypeMappings map[string]string `json:"issueTypeMappings"`
+}
+
+type JiraWorkflowSearchResponse struct {
+	Values []JiraWorkflow `json:"values"`
+}
+
+type JiraWorkflow struct {
+	ID          JiraWorkflowID           `json:"id"`
+	Transitions []JiraWorkflowTransition `json:"transitions"`
+	Statuses    []JiraNameField          `json:"statuses"`
+}
+
+type JiraWorkflowID struct {
+	Name string `json:"name"`
+}
+
+// JiraWorkflowTransition is a transition in a workflow definition. An empty
+// From means the transition is global (available from every status).
+type JiraWorkflowTransition struct {
+	ID   string   `json:"id"`
+	Name string   `json:"name"`
+	From []string `json:"from"`
+	To   string   `json:"to"`
+	Type string   `json:"type"`
+}
+
+// jiraPathIssue is the subset of an issue needed to find its workflow.
+type jiraPathIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Status    *JiraNameField `json:"status"`
+		IssueType *JiraNameField `json:"issuetype"`
+		Project   *JiraNameField `json:"project"`
+	} `json:"fields"`
+}
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type TransitionStep struct {
+	Transition string `json:"transition"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+}
+
+type TransitionPathResult struct {
+	Key     string           `json:"key"`
+	From    string           `json:"from"`
+	Status  string           `json:"status"`
+	Method  string           `json:"method"`
+	Steps   []TransitionStep `json:"steps"`
+	Fields  []string         `json:"fields,omitempty"`
+	Comment string           `json:"comment,omitempty"`
+	DryRun  bool             `json:"dry_run,omitempty"`
+	URL     string           `json:"url"`
+}
+
+// workflowHop is one planned step: a transition from one status to another.
+type workflowHop struct {
+	TransitionID string
+	Transition   string
+	FromID       string
+	From         string
+	ToID         string
+	To           string
+}
+
+// ---------------------------------------------------------------------------
+// Path transitions
+// ---------------------------------------------------------------------------
+
+func runPathTransition(cfg Config, issueKey, status string, opts TransitionOptions, dryRun, jsonOut bool) error {
+	result, err := transitionIssueByPath(cfg, issueKey, status, opts, dryRun)
+	if err != nil {
+		if len(result.Steps) == 0 || dryRun {
+			return err
+		}
+		// Report the hops already taken so a partial move isn't a surprise.
+		for _, s := range result.Steps {
+			fmt.Fprintf(os.Stderr, "done: %s -> %s [%s]\n", s.From, s.To, s.Transition)
+		}
+		return fmt.Errorf("%w (stopped at %s after %d steps)", err, result.Status, len(result.Steps))
+	}
+	if jsonOut {
+		return printJSON(result)
+	}
+
+	if len(result.Steps) == 0 {
+		fmt.Printf("%s is already in %s\n", result.Key, result.Status)
+		return nil
+	}
+	verb := "Transitioned"
+	if dryRun {
+		verb = "Would transition"
+	}
+	fmt.Printf("%s %s: %s -> %s in %d steps (%s)\n", verb, result.Key, result.From, result.Status, len(result.Steps), result.Method)
+	for i, s := range result.Steps {
+		fmt.Printf("  %d. %s -> %s  [%s]\n", i+1, s.From, s.To, s.Transition)
+	}
+	return nil
+}
+
+// transitionIssueByPath moves an issue to status through as many transitions
+// as needed. It plans the shortest path from the workflow definition when it
+// is readable (that usually needs admin rights) and otherwise explores the
+// available transitions step by step. opts apply to the final hop only.
+func transitionIssueByPath(cfg Config, issueKey, status string, opts TransitionOptions, dryRun bool) (TransitionPathResult, error) {
+	issue, err := getPathIssue(cfg, issueKey)
+	if err != nil {
+		return TransitionPathResult{}, err
+	}
+	current := issue.Fields.Status
+	if current == nil {
+		return TransitionPathResult{}, fmt.Errorf("%s has no status", issueKey)
+	}
+
+	result := TransitionPathResult{
+		Key:     issueKey,
+		From:    current.Name,
+		Status:  current.Name,
+		Comment: opts.Comment,
+		DryRun:  dryRun,
+		URL:     cfg.Server + "/browse/" + issueKey,
+	}
+	if _, tier := fuzzyMatchNames([]string{current.Name}, status); tier == "exact" {
+		result.Method = "none"
+		result.Steps = []TransitionStep{}
+		return result, nil
+	}
+
+	workflow, wfErr := getIssueWorkflow(cfg, issue)
+	if wfErr != nil {
+		// Exploring performs real transitions, so only fall back to it when
+		// the workflow is hidden from us, not when the request just failed.
+		if !errors.Is(wfErr, errWorkflowNotFound) && !isHTTPStatus(wfErr, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound) {
+			return result, fmt.Errorf("read workflow: %w", wfErr)
+		}
+		if dryRun {
+			return previewDirectTransition(cfg, result, status, wfErr)
+		}
+		result.Method = "explore"
+		return exploreTransitionPath(cfg, result, status, opts)
+	}
+
+	hops, err := findWorkflowPath(workflow, current.ID, status)
+	if err != nil {
+		return result, err
+	}
+	result.Method = "workflow"
+	result.Steps = make([]TransitionStep, 0, len(hops))
+	if dryRun {
+		for _, h := range hops {
+			result.Steps = append(result.Steps, TransitionStep{Transition: h.Transition, From: h.From, To: h.To})
+		}
+		result.Status = hops[len(hops)-1].To
+		return result, nil
+	}
+
+	// Steps and Status only ever reflect hops that actually happened.
+	for i, h := range hops {
+		hopOpts := TransitionOptions{}
+		last := i == len(hops)-1
+		if last {
+			hopOpts = opts
+		}
+		fields, err := executeHop(cfg, issueKey, h, hopOpts)
+		if err != nil {
+			return result, fmt.Errorf("step %d (%s -> %s) failed: %w", i+1, h.From, h.To, err)
+		}
+		result.Steps = append(result.Steps, TransitionStep{Transition: h.Transition, From: h.From, To: h.To})
+		result.Status = h.To
+		if last {
+			result.Fields = sortedKeys(fields)
+		}
+	}
+	return result, nil
+}
+
+// previewDirectTransition is the dry run used when the workflow definition
+// can't be read: it only previews a transition available from the current
+// status, since exploring further would change the issue.
+func previewDirectTransition(cfg Config, result TransitionPathResult, status string, wfErr error) (TransitionPathResult, error) {
+	transitions, err := getTransitions(cfg, result.Key)
+	if err != nil {
+		return result, err
+	}
+	matched, _, _, err := matchTransitionOrTarget(transitions, status)
+	if err != nil {
+		return result, fmt.Errorf("no direct transition to %q from %s, and a multi-step path can only be previewed from the workflow definition, "+
+			"which needs Jira admin rights (%v); run without --dry-run to explore step by step", status, result.Status, wfErr)
+	}
+	to := firstNonEmpty(nameOrEmpty(matched.To), matched.Name)
+	result.Method = "direct"
+	result.Steps = []TransitionStep{{Transition: matched.Name, From: result.Status, To: to}}
+	result.Status = to
+	return result, nil
+}
+
+// executeHop performs one planned transition. The transition is looked up by
+// ID in the issue's current transitions, falling back to its target status.
+func executeHop(cfg Config, issueKey string, h workflowHop, opts TransitionOptions) (map[string]any, error) {
+	transitions, err := getTransitions(cfg, issueKey)
+	if err != nil {
+		return nil, err
+	}
+	var picked *JiraTransition
+	for i, t := range transitions {
+		if t.ID == h.TransitionID {
+			picked = &transitions[i]
+			break
+		}
+	}
+	if picked == nil {
+		for i, t := range transitions {
+			if strings.EqualFold(nameOrEmpty(t.To), h.To) {
+				picked = &transitions[i]
+				break
+			}
+		}
+	}
+	if picked == nil {
+		return nil, fmt.Errorf("transition %q is not available; available transitions: %s", h.Transition, strings.Join(transitionLabels(transitions), ", "))
+	}
+
+	fields, update, err := buildTransitionFields(cfg, issueKey, *picked, opts)
+	if err != nil {
+		return nil, err
+	}
+	return fields, doTransition(cfg, issueKey, picked.ID, fields, update)
+}
+
+// exploreTransitionPath walks the workflow without its definition: take the
+// target transition if available, otherwise move to an unvisited status,
+// preferring ones further along (To Do < In Progress < Done) and never a
+// different done status.
+func exploreTransitionPath(cfg Config, result TransitionPathResult, status string, opts TransitionOptions) (TransitionPathResult, error) {
+	statuses, err := getStatuses(cfg)
+	if err != nil {
+		return result, err
+	}
+	category := map[string]string{}
+	for _, s := range statuses {
+		if s.StatusCategory != nil {
+			category[strings.ToLower(s.Name)] = s.StatusCategory.Key
+		}
+	}
+	rank := map[string]int{"new": 1, "indeterminate": 2, "done": 3}
+
+	visited := map[string]bool{strings.ToLower(result.From): true}
+	result.Steps = []TransitionStep{}
+	for hop := 0; hop < maxPathHops; hop++ {
+		transitions, err := getTransitions(cfg, result.Key)
+		if err != nil {
+			return result, err
+		}
+
+		if matched, _, _, err := matchTransitionOrTarget(transitions, status); err == nil {
+			fields, update, err := buildTransitionFields(cfg, result.Key, matched, opts)
+			if err != nil {
+				return result, err
+			}
+			if err := doTransition(cfg, result.Key, matched.ID, fields, update); err != nil {
+				return result, err
+			}
+			to := firstNonEmpty(nameOrEmpty(matched.To), matched.Name)
+			result.Steps = append(result.Steps, TransitionStep{Transition: matched.Name, From: result.Status, To: to})
+			result.Status = to
+			result.Fields = sortedKeys(fields)
+			return result, nil
+		}
+
+		var next *JiraTransition
+		for i, t := range transitions {
+			to := strings.ToLower(nameOrEmpty(t.To))
+			if to == "" || visited[to] || category[to] == "done" {
+				continue
+			}
+			if next == nil || rank[category[to]] > rank[category[strings.ToLower(nameOrEmpty(next.To))]] {
+				next = &transitions[i]
+			}
+		}
+		if next == nil {
+			return result, fmt.Errorf("no path to %q found from %s after %d steps", status, result.Status, len(result.Steps))
+		}
+
+		fields, update, err := buildTransitionFields(cfg, result.Key, *next, TransitionOptions{})
+		if err != nil {
+			return result, err
+		}
+		if err := doTransition(cfg, result.Key, next.ID, fields, update); err != nil {
+			return result, err
+		}
+		to := nameOrEmpty(next.To)
+		visited[strings.ToLower(to)] = true
+		result.Steps = append(result.Steps, TransitionStep{Transition: next.Name, From: result.Status, To: to})
+		result.Status = to
+	}
+	return result, fmt.Errorf("no path to %q within %d steps; stopped at %s", status, maxPathHops, result.Status)
+}
+
+// findWorkflowPath finds the shortest sequence of transitions from the status
+// fromID to a status matching target (exact > prefix > contains).
+func findWorkflowPath(wf JiraWorkflow, fromID, target string) ([]workflowHop, error) {
+	names := map[string]string{}
+	statusNames := make([]string, len(wf.Statuses))
+	for i, s := range wf.Statuses {
+		names[s.ID] = s.Name
+		statusNames[i] = s.Name
+	}
+
+	goals := map[string]bool{}
+	indexes, _ := fuzzyMatchNames(statusNames, target)
+	for _, i := range indexes {
+		goals[wf.Statuses[i].ID] = true
+	}
+	if len(goals) == 0 {
+		return nil, fmt.Errorf("workflow %q has no status matching %q; statuses: %s", wf.ID.Name, target, strings.Join(statusNames, ", "))
+	}
+
+	// Breadth-first search over statuses; prev records how each was reached.
+	prev := map[string]workflowHop{}
+	seen := map[string]bool{fromID: true}
+	queue := []string{fromID}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if goals[cur] && cur != fromID {
+			var hops []workflowHop
+			for s := cur; s != fromID; s = prev[s].FromID {
+				hops = append([]workflowHop{prev[s]}, hops...)
+			}
+			if len(hops) > maxPathHops {
+				return nil, fmt.Errorf("shortest path to %q takes %d steps (limit %d)", target, len(hops), maxPathHops)
+			}
+			return hops, nil
+		}
+		for _, t := range wf.Transitions {
+			if t.Type == "initial" || seen[t.To] || !transitionAvailableFrom(t, cur) {
+				continue
+			}
+			seen[t.To] = true
+			prev[t.To] = workflowHop{
+				TransitionID: t.ID,
+				Transition:   t.Name,
+				FromID:       cur,
+				From:         names[cur],
+				ToID:         t.To,
+				To:           names[t.To],
+			}
+			queue = append(queue, t.To)
+		}
+	}
+	return nil, fmt.Errorf("no path in workflow %q from %s to %q", wf.ID.Name, names[fromID], target)
+}
+
+func transitionAvailableFrom(t JiraWorkflowTransition, statusID string) bool {
+	if len(t.From) == 0 {
+		return true
+	}
+	return containsString(t.From, statusID)
+}
+
+// ---------------------------------------------------------------------------
+// Jira API calls
+// ---------------------------------------------------------------------------
+
+func getPathIssue(cfg Config, issueKey string) (jiraPathIssue, error) {
+	q := url.Values{}
+	q.Set("fields", "status,issuetype,project")
+	var issue jiraPathIssue
+	err := jiraDo(cfg, http.MethodGet, "/rest/api/3/issue/"+url.PathEscape(issueKey), q, nil, &issue)
+	return issue, err
+}
+
+// getIssueWorkflow reads the workflow used by the issue's project and type.
+func getIssueWorkflow(cfg Config, issue jiraPathIssue) (JiraWorkflow, error) {
+	if issue.Fields.Project == nil || issue.Fields.IssueType == nil {
+		return JiraWorkflow{}, fmt.Errorf("%w: issue has no project or issue type", errWorkflowNotFound)
+	}
+
+	q := url.Values{}
+	q.Set("projectId", issue.Fields.Project.ID)
+	var schemes JiraWorkflowSchemeProjects
+	if err := jiraDo(cfg, http.MethodGet, "/rest/api/3/workflowscheme/project", q, nil, &schemes); err != nil {
+		return JiraWorkflow{}, err
+	}
+	if len(schemes.Values) == 0 {
+		return JiraWorkflow{}, fmt.Errorf("%w: no workflow scheme for project", errWorkflowNotFound)
+	}
+	scheme := schemes.Values[0].WorkflowScheme
+	name := scheme.IssueTypeMappings[issue.Fields.IssueType.ID]
+	if name == "" {
+		name = scheme.DefaultWorkflow
+	}
+	if name == "" {
+		return JiraWorkflow{}, fmt.Errorf("%w: no workflow mapped to issue type", errWorkflowNotFound)
+	}
+
+	q = url.Values{}
+	q.Set("workflowName", name)
+	q.Set("expand", "transitions,statuses")
+	var found JiraWorkflowSearchResponse
+	if err := jiraDo(cfg, http.MethodGet, "/rest/api/3/workflow/search", q, nil, &found); err != nil {
+		return JiraWorkflow{}, err
+	}
+	for _, wf := range found.Values {
+		if wf.ID.Name == name {
+			return wf, nil
+		}
+	}
+	return JiraWorkflow{}, fmt.Errorf("%w: %q", errWorkflowNotFound, name)
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFindWorkflowPathShortestRoute(t *testing.T) {
+	wf := JiraWorkflow{
+		ID: JiraWorkflowID{Name: "Software"},
+		Statuses: []JiraNameField{
+			{ID: "1", Name: "To Do"}, {ID: "3", Name: "In Progress"}, {ID: "4", Name: "In Review"},
+			{ID: "5", Name: "Done"}, {ID: "6", Name: "Blocked"},
+		},
+		Transitions: []JiraWorkflowTransition{
+			{ID: "1", Name: "Create", To: "1", Type: "initial"},
+			{ID: "11", Name: "Start", From: []string{"1", "6"}, To: "3"},
+			{ID: "21", Name: "Submit", From: []string{"3"}, To: "4"},
+			{ID: "31", Name: "Approve", From: []string{"4"}, To: "5"},
+			{ID: "41", Name: "Reopen", From: []string{"5"}, To: "1"},
+			{ID: "51", Name: "Block", To: "6", Type: "global"},
+		},
+	}
+
+	hops, err := findWorkflowPath(wf, "1", "done")
+	if err != nil {
+		t.Fatalf("findWorkflowPath returned error: %v", err)
+	}
+	var names []string
+	for _, h := range hops {
+		names = append(names, h.Transition)
+	}
+	if len(hops) != 3 || names[0] != "Start" || names[1] != "Submit" || names[2] != "Approve" {
+		t.Fatalf("unexpected path %v", names)
+	}
+	if hops[2].From != "In Review" || hops[2].To != "Done" {
+		t.Fatalf("unexpected last hop %+v", hops[2])
+	}
+
+	// Global transitions are available from any status.
+	hops, err = findWorkflowPath(wf, "4", "Blocked")
+	if err != nil || len(hops) != 1 || hops[0].TransitionID != "51" {
+		t.Fatalf("expected single global hop, got %+v (err %v)", hops, err)
+	}
+
+	if _, err := findWorkflowPath(wf, "1", "Archived"); err == nil {
+		t.Fatalf("expected error for unknown status")
+	}
+}
+
+func TestTransitionIssueByPathExploresWithoutWorkflowAccess(t *testing.T) {
+	current := "To Do"
+	workflow := map[string][]JiraTransition{
+		"To Do":       {{ID: "11", Name: "Start", To: &JiraNameField{Name: "In Progress"}}, {ID: "99", Name: "Won't do", To: &JiraNameField{Name: "Rejected"}}},
+		"In Progress": {{ID: "21", Name: "Submit", To: &JiraNameField{Name: "In Review"}}, {ID: "12", Name: "Stop", To: &JiraNameField{Name: "To Do"}}},
+		"In Review":   {{ID: "31", Name: "Approve", To: &JiraNameField{Name: "Done"}}},
+	}
+	ids := map[string]string{}
+	for _, ts := range workflow {
+		for _, tr := range ts {
+			ids[tr.ID] = tr.To.Name
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/PROJ-3", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]any{"key": "PROJ-3", "fields": map[string]any{
+			"status":    map[string]any{"id": "1", "name": current},
+			"issuetype": map[string]any{"id": "10001", "name": "Task"},
+			"project":   map[string]any{"id": "10000", "name": "Project"},
+		}})
+	})
+	mux.HandleFunc("/rest/api/3/workflowscheme/project", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		writeJSON(t, w, map[string]any{"errorMessages": []string{"admin only"}})
+	})
+	mux.HandleFunc("/rest/api/3/status", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, []map[string]any{
+			{"name": "To Do", "statusCategory": map[string]any{"key": "new"}},
+			{"name": "In Progress", "statusCategory": map[string]any{"key": "indeterminate"}},
+			{"name": "In Review", "statusCategory": map[string]any{"key": "indeterminate"}},
+			{"name": "Done", "statusCategory": map[string]any{"key": "done"}},
+			{"name": "Rejected", "statusCategory": map[string]any{"key": "done"}},
+		})
+	})
+	mux.HandleFunc("/rest/api/3/issue/PROJ-3/transitions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			writeJSON(t, w, map[string]any{"transitions": workflow[current]})
+			return
+		}
+		var req JiraTransitionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		current = ids[req.Transition.ID]
+		w.WriteHeader(http.StatusNoContent)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+
+	// Without the workflow, a dry run previews direct transitions only.
+	preview, err := transitionIssueByPath(cfg, "PROJ-3", "In Progress", TransitionOptions{}, true)
+	if err != nil || preview.Method != "direct" || len(preview.Steps) != 1 || current != "To Do" {
+		t.Fatalf("unexpected direct preview %+v, %v (server status %s)", preview, err, current)
+	}
+	if _, err := transitionIssueByPath(cfg, "PROJ-3", "Done", TransitionOptions{}, true); err == nil || !strings.Contains(err.Error(), "admin rights") {
+		t.Fatalf("expected the multi-step dry run to explain it needs admin rights, got %v", err)
+	}
+
+	result, err := transitionIssueByPath(cfg, "PROJ-3", "Done", TransitionOptions{}, false)
+	if err != nil {
+		t.Fatalf("transitionIssueByPath returned error: %v", err)
+	}
+	if result.Method != "explore" || result.Status != "Done" || current != "Done" {
+		t.Fatalf("unexpected result %+v (server status %s)", result, current)
+	}
+	if len(result.Steps) != 3 || result.Steps[0].To != "In Progress" || result.Steps[1].To != "In Review" {
+		t.Fatalf("unexpected steps %+v", result.Steps)
+	}
+}
+
+func TestTransitionIssueByPathDoesNotExploreOnServerErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/issue/PROJ-3", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]any{"key": "PROJ-3", "fields": map[string]any{
+			"status":    map[string]any{"id": "1", "name": "To Do"},
+			"issuetype": map[string]any{"id": "10001", "name": "Task"},
+			"project":   map[string]any{"id": "10000", "name": "Project"},
+		}})
+	})
+	mux.HandleFunc("/rest/api/3/workflowscheme/project", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		writeJSON(t, w, map[string]any{"errorMessages": []string{"try again later"}})
+	})
+	mux.HandleFunc("/rest/api/3/issue/PROJ-3/transitions", func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("no transition may be explored after a server error")
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	cfg := Config{Server: ts.URL, Email: "user@example.com", APIToken: "token"}
+	_, err := transitionIssueByPath(cfg, "PROJ-3", "Done", TransitionOptions{}, false)
+	if err == nil || !strings.Contains(err.Error(), "try again later") {
+		t.Fatalf("expected the workflow read error, got %v", err)
+	}
+}
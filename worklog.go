@@ -0,0 +1,647 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// jiraTimeLayout is the timestamp format Jira expects in request bodies.
+const jiraTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// Jira's default time tracking configuration: 8h days, 5d weeks.
+const (
+	workDaySeconds  = 8 * 3600
+	workWeekSeconds = 5 * workDaySeconds
+)
+
+// ---------------------------------------------------------------------------
+// Jira API response types
+// ---------------------------------------------------------------------------
+
+type JiraWorklog struct {
+	ID               string    `json:"id"`
+	IssueID          string    `json:"issueId"`
+	Author           *JiraUser `json:"author"`
+	Comment          any       `json:"comment"`
+	Started          string    `json:"started"`
+	TimeSpent        string    `json:"timeSpent"`
+	TimeSpentSeconds int       `json:"timeSpentSeconds"`
+}
+
+type JiraWorklogsResponse struct {
+	StartAt    int           `json:"startAt"`
+	MaxResults int           `json:"maxResults"`
+	Total      int           `json:"total"`
+	Worklogs   []JiraWorklog `json:"worklogs"`
+}
+
+type JiraWorklogRequest struct {
+	Started          string           `json:"started"`
+	TimeSpentSeconds int              `json:"timeSpentSeconds"`
+	Comment          *JiraADFDocument `json:"comment,omitempty"`
+}
+
+// ---------------------------------------------------------------------------
+// Compact output types
+// ---------------------------------------------------------------------------
+
+type WorklogView struct {
+	ID        string `json:"id"`
+	Key       string `json:"key"`
+	Author    string `json:"author"`
+	Started   string `json:"started"`
+	TimeSpent string `json:"time_spent"`
+	Seconds   int    `json:"seconds"`
+	Comment   string `json:"comment,omitempty"`
+}
+
+type WorklogListView struct {
+	Key          string        `json:"key"`
+	Count        int           `json:"count"`
+	TotalSeconds int           `json:"total_seconds"`
+	Worklogs     []WorklogView `json:"worklogs"`
+}
+
+type WorklogResult struct {
+	Key       string `json:"key"`
+	ID        string `json:"id,omitempty"`
+	Started   string `json:"started"`
+	TimeSpent string `json:"time_spent"`
+	Seconds   int    `json:"seconds"`
+	Comment   string `json:"comment,omitempty"`
+	DryRun    bool   `json:"dry_run,omitempty"`
+	URL       string `json:"url"`
+}
+
+type WorklogDeleteResult struct {
+	Key     string `json:"key"`
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+type WorklogReport struct {
+	Since        string               `json:"since"`
+	Until        string               `json:"until"`
+	Author       string               `json:"author"`
+	TotalSeconds int                  `json:"total_seconds"`
+	TotalHours   float64              `json:"total_hours"`
+	DayHours     map[string]float64   `json:"day_hours"`
+	Issues       []WorklogReportIssue `json:"issues"`
+}
+
+type WorklogReportIssue struct {
+	Key          string             `json:"key"`
+	Summary      string             `json:"summary"`
+	TotalSeconds int                `json:"total_seconds"`
+	Hours        float64            `json:"hours"`
+	DayHours     map[string]float64 `json:"day_hours"`
+	URL          string             `json:"url"`
+}
+
+// ---------------------------------------------------------------------------
+// Help functions
+// ---------------------------------------------------------------------------
+
+func printWorklogHelp() {
+	fmt.Println("jiractl worklog commands:")
+	fmt.Println("  worklog add     ISSUE-KEY --time 1h30m [--started \"2026-10-16 09:00\"] [--comment TEXT] [--dry-run] [--json]")
+	fmt.Println("  worklog list    ISSUE-KEY [--json]")
+	fmt.Println("  worklog delete  ISSUE-KEY WORKLOG-ID [--json]")
+	fmt.Println("  worklog report  [--since monday] [--until DATE] [--author me|EMAIL|all] [--jql \"...\"] [--json]")
+}
+
+// ---------------------------------------------------------------------------
+// Worklog commands
+// ---------------------------------------------------------------------------
+
+func runWorklog(args []string) error {
+	if len(args) == 0 {
+		printWorklogHelp()
+		return nil
+	}
+
+	switch args[0] {
+	case "add":
+		return runWorklogAdd(args[1:])
+	case "list":
+		return runWorklogList(args[1:])
+	case "delete":
+		return runWorklogDelete(args[1:])
+	case "report":
+		return runWorklogReport(args[1:])
+	case "help", "--help", "-h":
+		printWorklogHelp()
+		return nil
+	default:
+		printWorklogHelp()
+		return fmt.Errorf("unknown worklog command %q", args[0])
+	}
+}
+
+func runWorklogAdd(args []string) error {
+	fs := flag.NewFlagSet("worklog add", flag.ContinueOnError)
+	spent := fs.String("time", "", "time spent, e.g. 1h30m, 45m, 1d (required)")
+	started := fs.String("started", "", "start time, e.g. \"2026-10-16 09:00\" (default: now minus --time)")
+	comment := fs.String("comment", "", "worklog comment")
+	dryRun := fs.Bool("dry-run", false, "show the worklog without posting it")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("issue key is required (e.g. jiractl worklog add PROJ-123 --time 1h30m)")
+	}
+	issueKey := strings.ToUpper(positional[0])
+
+	if *spent == "" {
+		return errors.New("--time is required (e.g. --time 1h30m)")
+	}
+	seconds, err := parseWorkDuration(*spent)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	start := now.Add(-time.Duration(seconds) * time.Second)
+	if *started != "" {
+		start, err = parseLocalTime(*started, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	result, err := logWork(cfg, issueKey, start, seconds, *comment, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	if *jsonOut {
+		return printJSON(result)
+	}
+	if *dryRun {
+		fmt.Printf("Would log %s on %s starting %s\n", result.TimeSpent, result.Key, result.Started)
+		return nil
+	}
+	fmt.Printf("Logged %s on %s starting %s (worklog %s)\n", result.TimeSpent, result.Key, result.Started, result.ID)
+	return nil
+}
+
+func runWorklogList(args []string) error {
+	fs := flag.NewFlagSet("worklog list", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("issue key is required (e.g. jiractl worklog list PROJ-123)")
+	}
+	issueKey := strings.ToUpper(positional[0])
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	worklogs, err := getWorklogs(cfg, issueKey)
+	if err != nil {
+		return err
+	}
+
+	out := WorklogListView{Key: issueKey, Count: len(worklogs), Worklogs: make([]WorklogView, 0, len(worklogs))}
+	for _, w := range worklogs {
+		out.TotalSeconds += w.TimeSpentSeconds
+		out.Worklogs = append(out.Worklogs, worklogToView(issueKey, w))
+	}
+
+	if *jsonOut {
+		return printJSON(out)
+	}
+
+	if len(out.Worklogs) == 0 {
+		fmt.Printf("No worklogs on %s.\n", issueKey)
+		return nil
+	}
+	fmt.Printf("Worklogs on %s (%d, total %s):\n", issueKey, out.Count, formatWorkDuration(out.TotalSeconds))
+	for _, w := range out.Worklogs {
+		fmt.Printf("- %-8s  %s  %-8s  %s", w.ID, w.Started, w.TimeSpent, w.Author)
+		if w.Comment != "" {
+			fmt.Printf("  %s", w.Comment)
+		}
+		fmt.Println()
+	}
+	return nil
+}
+
+func runWorklogDelete(args []string) error {
+	fs := flag.NewFlagSet("worklog delete", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print JSON")
+	positional, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 2 {
+		return errors.New("issue key and worklog ID are required (e.g. jiractl worklog delete PROJ-123 10042)")
+	}
+	issueKey := strings.ToUpper(positional[0])
+	worklogID := positional[1]
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := deleteWorklog(cfg, issueKey, worklogID); err != nil {
+		return err
+	}
+
+	result := WorklogDeleteResult{Key: issueKey, ID: worklogID, Deleted: true}
+	if *jsonOut {
+		return printJSON(result)
+	}
+	fmt.Printf("Worklog %s deleted from %s\n", worklogID, issueKey)
+	return nil
+}
+
+func runWorklogReport(args []string) error {
+	fs := flag.NewFlagSet("worklog report", flag.ContinueOnError)
+	since := fs.String("since", "monday", "first day: today, yesterday, a weekday, Nd (days ago) or YYYY-MM-DD")
+	until := fs.String("until", "", "last day, inclusive (default: today)")
+	author := fs.String("author", "me", "worklog author: me, an email, or all")
+	jql := fs.String("jql", "", "restrict to issues matching this JQL")
+	limit := fs.Int("limit", 200, "max issues to scan")
+	jsonOut := fs.Bool("json", false, "print JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *limit <= 0 {
+		return errors.New("--limit must be greater than 0")
+	}
+
+	now := time.Now()
+	from, err := parseDayExpr(*since, now)
+	if err != nil {
+		return err
+	}
+	to := dayStart(now)
+	if *until != "" {
+		to, err = parseDayExpr(*until, now)
+		if err != nil {
+			return err
+		}
+	}
+	if to.Before(from) {
+		return errors.New("--until must not be before --since")
+	}
+
+	cfg, err := loadAuthConfig()
+	if err != nil {
+		return err
+	}
+
+	clauses := []string{
+		fmt.Sprintf("worklogDate >= %q", from.Format("2006-01-02")),
+		fmt.Sprintf("worklogDate <= %q", to.Format("2006-01-02")),
+	}
+	accountID := ""
+	authorLabel := "all"
+	if !strings.EqualFold(*author, "all") {
+		user, err := resolveUser(cfg, *author)
+		if err != nil {
+			return err
+		}
+		accountID = user.AccountID
+		authorLabel = firstNonEmpty(user.EmailAddress, user.DisplayName, user.AccountID)
+		clauses = append(clauses, fmt.Sprintf("worklogAuthor = %q", accountID))
+	}
+	query := strings.Join(clauses, " AND ")
+	// The report sorts by key itself, so drop any ORDER BY from --jql
+	// before wrapping it in parentheses.
+	if userJQL := strings.TrimSpace(orderByPattern.ReplaceAllString(*jql, "")); userJQL != "" {
+		query = "(" + userJQL + ") AND " + query
+	}
+
+	searchResult, err := searchIssues(cfg, query+" ORDER BY key ASC", *limit)
+	if err != nil {
+		return err
+	}
+
+	worklogs := map[string][]JiraWorklog{}
+	for _, issue := range searchResult.Issues {
+		logs, err := getWorklogs(cfg, issue.Key)
+		if err != nil {
+			return fmt.Errorf("%s: %w", issue.Key, err)
+		}
+		worklogs[issue.Key] = logs
+	}
+
+	report := buildWorklogReport(searchResult.Issues, worklogs, accountID, from, to.AddDate(0, 0, 1), cfg.Server)
+	report.Author = authorLabel
+
+	if *jsonOut {
+		return printJSON(report)
+	}
+
+	if len(report.Issues) == 0 {
+		fmt.Printf("No time logged between %s and %s.\n", report.Since, report.Until)
+		return nil
+	}
+	fmt.Printf("Time logged by %s, %s to %s: %s\n\n", report.Author, report.Since, report.Until, formatHours(report.TotalHours))
+	for _, is := range report.Issues {
+		fmt.Printf("- %-12s  %7s  %s\n", is.Key, formatHours(is.Hours), is.Summary)
+	}
+	fmt.Println()
+	fmt.Println("Per day:")
+	for _, day := range sortedKeys(report.DayHours) {
+		fmt.Printf("  %s  %7s\n", day, formatHours(report.DayHours[day]))
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------
+// Jira API calls
+// ---------------------------------------------------------------------------
+
+func getWorklogs(cfg Config, issueKey string) ([]JiraWorklog, error) {
+	path := "/rest/api/3/issue/" + url.PathEscape(issueKey) + "/worklog"
+	var all []JiraWorklog
+	for {
+		q := url.Values{}
+		q.Set("startAt", strconv.Itoa(len(all)))
+		q.Set("maxResults", "1000")
+
+		var page JiraWorklogsResponse
+		if err := jiraDo(cfg, http.MethodGet, path, q, nil, &page); err != nil {
+			return nil, err
+		}
+		all = append(all, page.Worklogs...)
+		if len(page.Worklogs) == 0 || len(all) >= page.Total {
+			return all, nil
+		}
+	}
+}
+
+func addWorklog(cfg Config, issueKey string, started time.Time, seconds int, comment string) (JiraWorklog, error) {
+	body := JiraWorklogRequest{
+		Started:          started.Format(jiraTimeLayout),
+		TimeSpentSeconds: seconds,
+	}
+	if strings.TrimSpace(comment) != "" {
+		doc := textToADF(comment)
+		body.Comment = &doc
+	}
+
+	var created JiraWorklog
+	path := "/rest/api/3/issue/" + url.PathEscape(issueKey) + "/worklog"
+	err := jiraDo(cfg, http.MethodPost, path, nil, body, &created)
+	return created, err
+}
+
+func deleteWorklog(cfg Config, issueKey, worklogID string) error {
+	path := "/rest/api/3/issue/" + url.PathEscape(issueKey) + "/worklog/" + url.PathEscape(worklogID)
+	return jiraDo(cfg, http.MethodDelete, path, nil, nil, nil)
+}
+
+// logWork posts a worklog (unless dryRun) and describes it as a result.
+func logWork(cfg Config, issueKey string, started time.Time, seconds int, comment string, dryRun bool) (WorklogResult, error) {
+	result := WorklogResult{
+		Key:       issueKey,
+		Started:   started.Format("2006-01-02 15:04"),
+		TimeSpent: formatWorkDuration(seconds),
+		Seconds:   seconds,
+		Comment:   comment,
+		DryRun:    dryRun,
+		URL:       cfg.Server + "/browse/" + issueKey,
+	}
+	if dryRun {
+		return result, nil
+	}
+	created, err := addWorklog(cfg, issueKey, started, seconds, comment)
+	if err != nil {
+		return result, err
+	}
+	result.ID = created.ID
+	return result, nil
+}
+
+// ---------------------------------------------------------------------------
+// Worklog helpers
+// ---------------------------------------------------------------------------
+
+// buildWorklogReport sums worklogs started in [from, to) per issue and day.
+// An empty accountID includes every author.
+func buildWorklogReport(issues []JiraIssue, worklogs map[string][]JiraWorklog, accountID string, from, to time.Time, server string) WorklogReport {
+	report := WorklogReport{
+		Since:    from.Format("2006-01-02"),
+		Until:    to.AddDate(0, 0, -1).Format("2006-01-02"),
+		DayHours: map[string]float64{},
+		Issues:   []WorklogReportIssue{},
+	}
+	daySeconds := map[string]int{}
+
+	for _, issue := range issues {
+		entry := WorklogReportIssue{
+			Key:      issue.Key,
+			Summary:  issue.Fields.Summary,
+			DayHours: map[string]float64{},
+			URL:      server + "/browse/" + issue.Key,
+		}
+		perDay := map[string]int{}
+		for _, w := range worklogs[issue.Key] {
+			if accountID != "" && (w.Author == nil || w.Author.AccountID != accountID) {
+				continue
+			}
+			started, err := parseJiraTime(w.Started)
+			if err != nil {
+				continue
+			}
+			started = started.In(from.Location())
+			if started.Before(from) || !started.Before(to) {
+				continue
+			}
+			day := started.Format("2006-01-02")
+			perDay[day] += w.TimeSpentSeconds
+			daySeconds[day] += w.TimeSpentSeconds
+			entry.TotalSeconds += w.TimeSpentSeconds
+		}
+		if entry.TotalSeconds == 0 {
+			continue
+		}
+		for day, secs := range perDay {
+			entry.DayHours[day] = secondsToHours(secs)
+		}
+		entry.Hours = secondsToHours(entry.TotalSeconds)
+		report.TotalSeconds += entry.TotalSeconds
+		report.Issues = append(report.Issues, entry)
+	}
+
+	for day, secs := range daySeconds {
+		report.DayHours[day] = secondsToHours(secs)
+	}
+	report.TotalHours = secondsToHours(report.TotalSeconds)
+	sort.Slice(report.Issues, func(i, j int) bool {
+		if report.Issues[i].TotalSeconds != report.Issues[j].TotalSeconds {
+			return report.Issues[i].TotalSeconds > report.Issues[j].TotalSeconds
+		}
+		return compareIssueKeys(report.Issues[i].Key, report.Issues[j].Key) < 0
+	})
+	return report
+}
+
+func worklogToView(issueKey string, w JiraWorklog) WorklogView {
+	started := w.Started
+	if t, err := parseJiraTime(w.Started); err == nil {
+		started = t.Format("2006-01-02 15:04")
+	}
+	return WorklogView{
+		ID:        w.ID,
+		Key:       issueKey,
+		Author:    userEmail(w.Author),
+		Started:   started,
+		TimeSpent: firstNonEmpty(w.TimeSpent, formatWorkDuration(w.TimeSpentSeconds)),
+		Seconds:   w.TimeSpentSeconds,
+		Comment:   adfToText(w.Comment),
+	}
+}
+
+// parseWorkDuration parses Jira-style durations such as "1h30m", "1h 30m",
+// "1.5h", "45m", "2d" or "1w" into seconds, using 8h days and 5d weeks.
+func parseWorkDuration(s string) (int, error) {
+	input := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), " ", ""))
+	if input == "" {
+		return 0, errors.New("empty duration")
+	}
+
+	total := 0.0
+	for input != "" {
+		i := 0
+		for i < len(input) && (unicode.IsDigit(rune(input[i])) || input[i] == '.') {
+			i++
+		}
+		if i == 0 || i == len(input) {
+			return 0, fmt.Errorf("invalid duration %q (use e.g. 1h30m, 45m, 1d)", s)
+		}
+		n, err := strconv.ParseFloat(input[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q (use e.g. 1h30m, 45m, 1d)", s)
+		}
+		unit := input[i]
+		input = input[i+1:]
+		switch unit {
+		case 'w':
+			total += n * workWeekSeconds
+		case 'd':
+			total += n * workDaySeconds
+		case 'h':
+			total += n * 3600
+		case 'm':
+			total += n * 60
+		case 's':
+			total += n
+		default:
+			return 0, fmt.Errorf("invalid duration unit %q in %q", string(unit), s)
+		}
+	}
+
+	seconds := int(math.Round(total))
+	if seconds < 60 {
+		return 0, fmt.Errorf("duration %q is shorter than one minute", s)
+	}
+	return seconds, nil
+}
+
+// formatWorkDuration renders seconds as hours and minutes ("1h 30m").
+func formatWorkDuration(seconds int) string {
+	h := seconds / 3600
+	m := (seconds % 3600) / 60
+	switch {
+	case h > 0 && m > 0:
+		return fmt.Sprintf("%dh %dm", h, m)
+	case h > 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dm", m)
+	}
+}
+
+// parseLocalTime parses a user-supplied timestamp in the local time zone.
+// A bare time ("09:00") refers to today.
+func parseLocalTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+	if t, err := time.ParseInLocation("15:04", s, now.Location()); err == nil {
+		y, m, d := now.Date()
+		return time.Date(y, m, d, t.Hour(), t.Minute(), 0, 0, now.Location()), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q (use \"YYYY-MM-DD HH:MM\" or \"HH:MM\")", s)
+}
+
+// parseDayExpr resolves today, yesterday, weekday names (the most recent
+// such day, including today), "Nd" (N days ago) and YYYY-MM-DD to the start
+// of that day.
+func parseDayExpr(s string, now time.Time) (time.Time, error) {
+	expr := strings.ToLower(strings.TrimSpace(s))
+	today := dayStart(now)
+
+	switch expr {
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		name := strings.ToLower(wd.String())
+		if expr == name || expr == name[:3] {
+			back := (int(now.Weekday()) - int(wd) + 7) % 7
+			return today.AddDate(0, 0, -back), nil
+		}
+	}
+	if strings.HasSuffix(expr, "d") {
+		if n, err := strconv.Atoi(strings.TrimSuffix(expr, "d")); err == nil && n >= 0 {
+			return today.AddDate(0, 0, -n), nil
+		}
+	}
+	if t, err := time.ParseInLocation("2006-01-02", expr, now.Location()); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q (use today, yesterday, a weekday, Nd or YYYY-MM-DD)", s)
+}
+
+func secondsToHours(seconds int) float64 {
+	return math.Round(float64(seconds)/36) / 100
+}
+
+func formatHours(h float64) string {
+	return strconv.FormatFloat(h, 'f', 2, 64) + "h"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
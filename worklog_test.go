@@ -0,0 +1,116 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseWorkDuration(t *testing.T) {
+	cases := map[string]int{
+		"1h30m":  5400,
+		"1h 30m": 5400,
+		"1.5h":   5400,
+		"45m":    2700,
+		"1d":     8 * 3600,
+		"1w":     5 * 8 * 3600,
+	}
+	for in, want := range cases {
+		got, err := parseWorkDuration(in)
+		if err != nil {
+			t.Fatalf("parseWorkDuration(%q) returned error: %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("parseWorkDuration(%q) = %d, want %d", in, got, want)
+		}
+	}
+	for _, bad := range []string{"", "90", "1x", "30s"} {
+		if _, err := parseWorkDuration(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestParseDayExprWeekdays(t *testing.T) {
+	// Thursday.
+	now := time.Date(2026, 10, 15, 14, 30, 0, 0, time.UTC)
+	cases := map[string]string{
+		"monday":     "2026-10-12",
+		"thu":        "2026-10-15",
+		"friday":     "2026-10-09",
+		"yesterday":  "2026-10-14",
+		"3d":         "2026-10-12",
+		"2026-09-30": "2026-09-30",
+	}
+	for in, want := range cases {
+		got, err := parseDayExpr(in, now)
+		if err != nil {
+			t.Fatalf("parseDayExpr(%q) returned error: %v", in, err)
+		}
+		if got.Format("2006-01-02") != want {
+			t.Fatalf("parseDayExpr(%q) = %s, want %s", in, got.Format("2006-01-02"), want)
+		}
+	}
+}
+
+func TestBuildWorklogReportFiltersAuthorAndRange(t *testing.T) {
+	me := &JiraUser{AccountID: "me-1"}
+	other := &JiraUser{AccountID: "other-2"}
+	issues := []JiraIssue{
+		{Key: "PROJ-1", Fields: JiraIssueFields{Summary: "One"}},
+		{Key: "PROJ-2", Fields: JiraIssueFields{Summary: "Two"}},
+	}
+	worklogs := map[string][]JiraWorklog{
+		"PROJ-1": {
+			{Author: me, Started: "2026-10-12T09:00:00.000+0000", TimeSpentSeconds: 3600},
+			{Author: me, Started: "2026-10-13T09:00:00.000+0000", TimeSpentSeconds: 1800},
+			{Author: other, Started: "2026-10-13T10:00:00.000+0000", TimeSpentSeconds: 7200},
+		},
+		"PROJ-2": {
+			{Author: me, Started: "2026-10-05T09:00:00.000+0000", TimeSpentSeconds: 3600},
+		},
+	}
+	from := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+
+	report := buildWorklogReport(issues, worklogs, "me-1", from, to, "https://example.atlassian.net")
+
+	if report.TotalSeconds != 5400 || report.TotalHours != 1.5 {
+		t.Fatalf("expected 1.5h total, got %d seconds (%v h)", report.TotalSeconds, report.TotalHours)
+	}
+	if len(report.Issues) != 1 || report.Issues[0].Key != "PROJ-1" {
+		t.Fatalf("expected only PROJ-1 in report, got %+v", report.Issues)
+	}
+	if report.DayHours["2026-10-13"] != 0.5 {
+		t.Fatalf("expected 0.5h on 2026-10-13, got %v", report.DayHours["2026-10-13"])
+	}
+	if report.Until != "2026-10-18" {
+		t.Fatalf("expected inclusive until date, got %s", report.Until)
+	}
+}
+
+func TestWorklogReportStripsOrderByFromJQL(t *testing.T) {
+	var gotJQL string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/rest/api/3/search/jql", func(w http.ResponseWriter, r *http.Request) {
+		gotJQL = r.URL.Query().Get("jql")
+		writeJSON(t, w, JiraSearchResponse{Issues: []JiraIssue{}})
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("JIRACTL_SERVER", ts.URL)
+	t.Setenv("JIRACTL_EMAIL", "user@example.com")
+	t.Setenv("JIRACTL_API_TOKEN", "token")
+
+	args := []string{"--author", "all", "--since", "2026-10-12", "--until", "2026-10-16", "--json",
+		"--jql", "project = PROJ ORDER BY created DESC"}
+	if err := runWorklogReport(args); err != nil {
+		t.Fatalf("runWorklogReport returned error: %v", err)
+	}
+	if !strings.HasPrefix(gotJQL, "(project = PROJ) AND worklogDate") || strings.Count(strings.ToUpper(gotJQL), "ORDER BY") != 1 {
+		t.Fatalf("unexpected JQL %q", gotJQL)
+	}
+}